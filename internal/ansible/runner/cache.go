@@ -0,0 +1,270 @@
+// Copyright 2021 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/operator-framework/operator-sdk/internal/ansible/flags"
+	"github.com/operator-framework/operator-sdk/internal/ansible/runner/eventapi"
+	"github.com/operator-framework/operator-sdk/internal/ansible/runner/internal/inputdir"
+	"github.com/operator-framework/operator-sdk/internal/ansible/watches"
+)
+
+// forwardTimeout is how long recording waits for the reconciler to receive an event,
+// matching the event API's timeout, before it stops forwarding events.
+const forwardTimeout = 10 * time.Second
+
+// resultCache is a size-bounded, least recently used cache of the result of the last successful run of
+// each CR, along with a hash of the run's inputs. A result is reused by later runs of the CR with identical
+// inputs, such as those of the reconcile period, until it is older than ttl. Ansible does not run while a
+// result is reused, so drift of managed resources is not corrected until the result expires.
+type resultCache struct {
+	ttl        time.Duration
+	maxEntries int
+	now        func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+}
+
+type cacheEntry struct {
+	obj     string
+	key     string
+	result  *cachedRunResult
+	expires time.Time
+}
+
+func newResultCache(rc watches.ResultCache) *resultCache {
+	return &resultCache{
+		ttl:        rc.TTL,
+		maxEntries: rc.MaxEntries,
+		now:        time.Now,
+		entries:    map[string]*list.Element{},
+		lru:        list.New(),
+	}
+}
+
+// get returns the result cached for the CR identified by obj if it has not expired and the run it is the
+// result of had inputs hashing to key. Annotations are part of key, so they are identical if a result
+// is returned.
+func (c *resultCache) get(obj, key string) (*cachedRunResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.entries[obj]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*cacheEntry)
+	if entry.key != key || !c.now().Before(entry.expires) {
+		return nil, false
+	}
+	c.lru.MoveToFront(elem)
+	return entry.result, true
+}
+
+// forget removes the result cached for the CR identified by obj, so it is not reused once a run with
+// different inputs, or one that is not cached, has started.
+func (c *resultCache) forget(obj string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.entries[obj]; ok {
+		c.lru.Remove(elem)
+		delete(c.entries, obj)
+	}
+}
+
+// add caches result for the CR identified by obj, evicting the least recently used entry if c is full.
+func (c *resultCache) add(obj, key string, result *cachedRunResult) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry := &cacheEntry{obj: obj, key: key, result: result, expires: c.now().Add(c.ttl)}
+	if elem, ok := c.entries[obj]; ok {
+		elem.Value = entry
+		c.lru.MoveToFront(elem)
+		return
+	}
+	c.entries[obj] = c.lru.PushFront(entry)
+	for c.lru.Len() > c.maxEntries {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).obj)
+	}
+}
+
+// record forwards events to the returned channel while keeping those the reconciler acts on. Once events
+// is closed, the kept events are cached for the CR identified by obj under key if the run succeeded, as
+// reported by exitErr.
+func (c *resultCache) record(obj, key string, result *cachedRunResult, events <-chan eventapi.JobEvent,
+	exitErr <-chan error) <-chan eventapi.JobEvent {
+
+	out := make(chan eventapi.JobEvent, cap(events))
+	go func() {
+		forwarding, finished, failed := true, false, false
+		for event := range events {
+			if forwarding {
+				forwarding = forward(out, event)
+				if !forwarding {
+					log.V(1).Info("Stopped forwarding events to the reconciler", "job", result.ident)
+				}
+			}
+			switch {
+			case event.Event == eventapi.EventPlaybookOnStats:
+				finished = true
+				result.events = append(result.events, event)
+			case event.Event == eventapi.EventRunnerOnFailed:
+				if !event.IgnoreError() && !event.Rescued() {
+					failed = true
+				}
+			case event.EventData["task_action"] == eventapi.TaskActionRequeueAfter:
+				result.events = append(result.events, event)
+			}
+		}
+		// exitErr is sent before events is closed, so this does not delay closing out.
+		if err := <-exitErr; err == nil && finished && !failed {
+			c.add(obj, key, result)
+		}
+		close(out)
+	}()
+	return out
+}
+
+func forward(out chan<- eventapi.JobEvent, event eventapi.JobEvent) bool {
+	timeout := time.NewTimer(forwardTimeout)
+	defer timeout.Stop()
+	select {
+	case out <- event:
+		return true
+	case <-timeout.C:
+		return false
+	}
+}
+
+// cacheKey returns a hash of the parameters of a run for u and the content of the files the run uses.
+// Fields of u that change without its inputs changing, such as status and generation, are excluded.
+func (r *runner) cacheKey(u *unstructured.Unstructured, parameters map[string]interface{}) (string, error) {
+	obj := u.DeepCopy()
+	unstructured.RemoveNestedField(obj.Object, "status")
+	unstructured.RemoveNestedField(obj.Object, "metadata", "generation")
+	unstructured.RemoveNestedField(obj.Object, "metadata", "resourceVersion")
+	unstructured.RemoveNestedField(obj.Object, "metadata", "managedFields")
+
+	keyParameters := make(map[string]interface{}, len(parameters))
+	for k, v := range parameters {
+		keyParameters[k] = v
+	}
+	keyParameters[r.objectKey()] = obj.Object
+
+	// Map keys are marshaled in sorted order, so identical parameters always hash the same.
+	data, err := json.Marshal(keyParameters)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	h.Write(data)
+	if err := hashContent(h, r.contentPaths); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// hashContent writes the path, size, and modification time of every file in paths to h, so the hash
+// changes when role or playbook content changes. Paths that do not exist are skipped.
+func hashContent(h io.Writer, paths []string) error {
+	for _, root := range paths {
+		err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				if path == root && os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+			if !info.IsDir() {
+				fmt.Fprintf(h, "%s\x00%d\x00%d\x00", path, info.Size(), info.ModTime().UnixNano())
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cacheContentPaths returns the paths a run of watch may read roles or playbooks from.
+func cacheContentPaths(watch watches.Watch) []string {
+	var paths []string
+	switch {
+	case watch.Playbook != "":
+		// Playbooks may include other playbooks and roles next to them.
+		paths = append(paths, filepath.Dir(watch.Playbook))
+	case watch.Role != "":
+		paths = append(paths, watch.Role)
+	}
+	if wd, err := os.Getwd(); err == nil {
+		paths = append(paths, filepath.Join(wd, "roles"))
+	}
+	for _, path := range filepath.SplitList(os.Getenv(flags.AnsibleRolesPathEnvVar)) {
+		if path != "" {
+			paths = append(paths, path)
+		}
+	}
+
+	sort.Strings(paths)
+	var unique []string
+	for _, path := range paths {
+		if len(unique) == 0 || path != unique[len(unique)-1] {
+			unique = append(unique, path)
+		}
+	}
+	return unique
+}
+
+// cachedRunResult is the result of a successful run that is reused for later runs with identical inputs.
+type cachedRunResult struct {
+	// events are the events of the run that the reconciler acts on.
+	events []eventapi.JobEvent
+
+	ident    string
+	inputDir *inputdir.InputDir
+}
+
+// Stdout returns the stdout of the cached run if its artifacts have not been rotated, else an error.
+func (r *cachedRunResult) Stdout() (string, error) {
+	return r.inputDir.Stdout(r.ident)
+}
+
+// Events returns the cached events of the run.
+func (r *cachedRunResult) Events() <-chan eventapi.JobEvent {
+	events := make(chan eventapi.JobEvent, len(r.events))
+	for _, event := range r.events {
+		events <- event
+	}
+	close(events)
+	return events
+}
@@ -0,0 +1,245 @@
+// Copyright 2021 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/operator-framework/operator-sdk/internal/ansible/runner/eventapi"
+	"github.com/operator-framework/operator-sdk/internal/ansible/watches"
+)
+
+func TestResultCache(t *testing.T) {
+	now := time.Now()
+	c := newResultCache(watches.ResultCache{TTL: time.Minute, MaxEntries: 2})
+	c.now = func() time.Time { return now }
+
+	for _, obj := range []string{"default/a", "default/b"} {
+		c.add(obj, "key", &cachedRunResult{ident: obj})
+	}
+	if result, ok := c.get("default/a", "key"); !ok || result.ident != "default/a" {
+		t.Fatalf("Expected cached result for default/a, got %v, %v", result, ok)
+	}
+
+	// default/b is now the least recently used entry, so adding default/c evicts it.
+	c.add("default/c", "key", &cachedRunResult{ident: "default/c"})
+	if _, ok := c.get("default/b", "key"); ok {
+		t.Fatalf("Expected default/b to be evicted")
+	}
+	if len(c.entries) != 2 || c.lru.Len() != 2 {
+		t.Fatalf("Expected at most 2 entries, got %d", len(c.entries))
+	}
+
+	// Later reconciles with identical inputs, such as those of the reconcile period, reuse the result
+	// until it expires.
+	for i := 0; i < 3; i++ {
+		if _, ok := c.get("default/c", "key"); !ok {
+			t.Fatalf("Expected cached result for default/c")
+		}
+	}
+	now = now.Add(time.Minute)
+	if _, ok := c.get("default/c", "key"); ok {
+		t.Fatalf("Expected default/c to be expired")
+	}
+}
+
+func TestResultCacheReuse(t *testing.T) {
+	c := newResultCache(watches.ResultCache{TTL: time.Minute, MaxEntries: 10})
+	c.add("default/example", "a", &cachedRunResult{ident: "a"})
+
+	if _, ok := c.get("default/example", "a"); !ok {
+		t.Fatalf("Expected result to be reused for identical inputs")
+	}
+	if _, ok := c.get("default/example", "b"); ok {
+		t.Fatalf("Expected result not to be reused for other inputs")
+	}
+	// Results are only reused for the CR they were cached for.
+	if _, ok := c.get("default/other", "a"); ok {
+		t.Fatalf("Expected result not to be reused for another CR")
+	}
+
+	// Once a run with other inputs starts, the result is stale even if the inputs change back.
+	c.forget("default/example")
+	if _, ok := c.get("default/example", "a"); ok {
+		t.Fatalf("Expected result not to be reused after a run with other inputs")
+	}
+	if len(c.entries) != 0 || c.lru.Len() != 0 {
+		t.Fatalf("Expected forgotten entry to be removed, got %d entries", len(c.entries))
+	}
+
+	// A newer result for a CR replaces its previous one.
+	c.add("default/example", "a", &cachedRunResult{ident: "a"})
+	c.add("default/example", "b", &cachedRunResult{ident: "b"})
+	if _, ok := c.get("default/example", "a"); ok {
+		t.Fatalf("Expected result to be replaced")
+	}
+	if result, ok := c.get("default/example", "b"); !ok || result.ident != "b" {
+		t.Fatalf("Expected cached result b, got %v, %v", result, ok)
+	}
+	if len(c.entries) != 1 || c.lru.Len() != 1 {
+		t.Fatalf("Expected one entry per CR, got %d entries", len(c.entries))
+	}
+}
+
+func TestResultCacheRecord(t *testing.T) {
+	stats := eventapi.JobEvent{Event: eventapi.EventPlaybookOnStats}
+	requeue := eventapi.JobEvent{
+		Event:     eventapi.EventRunnerOnOk,
+		EventData: map[string]interface{}{"task_action": eventapi.TaskActionRequeueAfter},
+	}
+	ok := eventapi.JobEvent{Event: eventapi.EventRunnerOnOk, EventData: map[string]interface{}{"task_action": "k8s"}}
+	failed := eventapi.JobEvent{Event: eventapi.EventRunnerOnFailed, EventData: map[string]interface{}{}}
+
+	testCases := []struct {
+		name           string
+		events         []eventapi.JobEvent
+		exitErr        error
+		expectedEvents []eventapi.JobEvent
+	}{
+		{
+			name:           "successful run is cached",
+			events:         []eventapi.JobEvent{ok, requeue, stats},
+			expectedEvents: []eventapi.JobEvent{requeue, stats},
+		},
+		{
+			name:   "run with failed task is not cached",
+			events: []eventapi.JobEvent{ok, failed, stats},
+		},
+		{
+			name:   "run without stats is not cached",
+			events: []eventapi.JobEvent{ok},
+		},
+		{
+			name:    "run that exited with an error is not cached",
+			events:  []eventapi.JobEvent{ok, stats},
+			exitErr: errors.New("exit status 1"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := newResultCache(watches.ResultCache{TTL: time.Minute, MaxEntries: 1})
+
+			events := make(chan eventapi.JobEvent, len(tc.events))
+			for _, event := range tc.events {
+				events <- event
+			}
+			close(events)
+			exitErr := make(chan error, 1)
+			exitErr <- tc.exitErr
+
+			var forwarded []eventapi.JobEvent
+			for event := range c.record("default/example", "key", &cachedRunResult{ident: "test"}, events, exitErr) {
+				forwarded = append(forwarded, event)
+			}
+			if len(forwarded) != len(tc.events) {
+				t.Fatalf("Expected all %d events to be forwarded, got %d", len(tc.events), len(forwarded))
+			}
+
+			result, cached := c.get("default/example", "key")
+			if cached != (tc.expectedEvents != nil) {
+				t.Fatalf("Unexpected cached %v", cached)
+			}
+			if !cached {
+				return
+			}
+			var replayed []eventapi.JobEvent
+			for event := range result.Events() {
+				replayed = append(replayed, event)
+			}
+			if len(replayed) != len(tc.expectedEvents) {
+				t.Fatalf("Expected %d replayed events, got %d", len(tc.expectedEvents), len(replayed))
+			}
+			for i, event := range replayed {
+				if event.Event != tc.expectedEvents[i].Event {
+					t.Fatalf("Unexpected replayed event %d: %s expected %s", i, event.Event, tc.expectedEvents[i].Event)
+				}
+			}
+		})
+	}
+}
+
+func TestCacheKey(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ansible-role")
+	if err != nil {
+		t.Fatalf("Unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	taskFile := filepath.Join(dir, "tasks", "main.yml")
+	if err := os.MkdirAll(filepath.Dir(taskFile), 0755); err != nil {
+		t.Fatalf("Unable to create tasks dir: %v", err)
+	}
+	if err := ioutil.WriteFile(taskFile, []byte("---\n"), 0644); err != nil {
+		t.Fatalf("Unable to write tasks: %v", err)
+	}
+
+	r := &runner{
+		GVK:                 schema.GroupVersionKind{Group: "operator.example.com", Version: "v1alpha1", Kind: "Example"},
+		snakeCaseParameters: true,
+		contentPaths:        []string{dir, filepath.Join(dir, "does-not-exist")},
+	}
+	newCR := func() *unstructured.Unstructured {
+		u := &unstructured.Unstructured{Object: map[string]interface{}{
+			"metadata": map[string]interface{}{"name": "example", "namespace": "default", "resourceVersion": "1"},
+			"spec":     map[string]interface{}{"size": int64(3)},
+		}}
+		u.SetGroupVersionKind(r.GVK)
+		return u
+	}
+	key := func(u *unstructured.Unstructured) string {
+		k, err := r.cacheKey(u, r.makeParameters(u))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return k
+	}
+
+	original := key(newCR())
+
+	u := newCR()
+	u.SetResourceVersion("2")
+	u.SetGeneration(2)
+	u.Object["status"] = map[string]interface{}{"conditions": []interface{}{}}
+	if key(u) != original {
+		t.Fatalf("Expected key to ignore status, generation and resourceVersion")
+	}
+
+	u = newCR()
+	u.Object["spec"] = map[string]interface{}{"size": int64(4)}
+	if key(u) == original {
+		t.Fatalf("Expected key to change with spec")
+	}
+
+	r.Vars = map[string]interface{}{"foo": "bar"}
+	if key(newCR()) == original {
+		t.Fatalf("Expected key to change with watch vars")
+	}
+	r.Vars = nil
+
+	if err := ioutil.WriteFile(taskFile, []byte("---\n- debug: {}\n"), 0644); err != nil {
+		t.Fatalf("Unable to write tasks: %v", err)
+	}
+	if key(newCR()) == original {
+		t.Fatalf("Expected key to change with role content")
+	}
+}
@@ -38,6 +38,8 @@ const (
 	TaskActionSetFact = "set_fact"
 	// TaskActionDebug - task action of printing a debug message.
 	TaskActionDebug = "debug"
+	// TaskActionRequeueAfter - task action of requeueing the reconcile after a period.
+	TaskActionRequeueAfter = "operator_sdk.util.requeue_after"
 
 	// defaultFailedMessage - Default failed playbook message
 	defaultFailedMessage = "unknown playbook failure"
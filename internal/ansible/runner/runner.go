@@ -149,7 +149,7 @@ func New(watch watches.Watch, runnerArgs string) (Runner, error) {
 		finalizerCmdFunc = cmdFunc
 	}
 
-	return &runner{
+	r := &runner{
 		Path:                path,
 		cmdFunc:             cmdFunc,
 		Vars:                watch.Vars,
@@ -161,7 +161,12 @@ func New(watch watches.Watch, runnerArgs string) (Runner, error) {
 		ansibleArgs:         runnerArgs,
 		snakeCaseParameters: watch.SnakeCaseParameters,
 		markUnsafe:          watch.MarkUnsafe,
-	}, nil
+	}
+	if watch.ResultCache != nil {
+		r.cache = newResultCache(*watch.ResultCache)
+		r.contentPaths = cacheContentPaths(watch)
+	}
+	return r, nil
 }
 
 // runner - implements the Runner interface for a GVK that's being watched.
@@ -177,6 +182,9 @@ type runner struct {
 	snakeCaseParameters bool
 	markUnsafe          bool
 	ansibleArgs         string
+	// cache holds results of successful runs to reuse, if enabled by the watch.
+	cache        *resultCache
+	contentPaths []string // paths hashed into cache keys
 }
 
 func (r *runner) Run(ident string, u *unstructured.Unstructured, kubeconfig string) (RunResult, error) {
@@ -192,6 +200,24 @@ func (r *runner) Run(ident string, u *unstructured.Unstructured, kubeconfig stri
 		"namespace", u.GetNamespace(),
 	)
 
+	parameters := r.makeParameters(u)
+	var cacheObj, cacheKey string
+	if r.cache != nil {
+		cacheObj = fmt.Sprintf("%s/%s", u.GetNamespace(), u.GetName())
+		if !r.isFinalizerRun(u) {
+			key, err := r.cacheKey(u, parameters)
+			if err != nil {
+				logger.Error(err, "Unable to compute result cache key, running without the result cache")
+			} else if cached, ok := r.cache.get(cacheObj, key); ok {
+				logger.Info("Reusing result of a previous run with identical inputs", "cachedJob", cached.ident)
+				return cached, nil
+			} else {
+				cacheKey = key
+			}
+		}
+		r.cache.forget(cacheObj)
+	}
+
 	// start the event receiver. We'll check errChan for an error after
 	// ansible-runner exits.
 	errChan := make(chan error, 1)
@@ -202,7 +228,7 @@ func (r *runner) Run(ident string, u *unstructured.Unstructured, kubeconfig stri
 	inputDir := inputdir.InputDir{
 		Path: filepath.Join("/tmp/ansible-operator/runner/", r.GVK.Group, r.GVK.Version, r.GVK.Kind,
 			u.GetNamespace(), u.GetName()),
-		Parameters: r.makeParameters(u),
+		Parameters: parameters,
 		EnvVars: map[string]string{
 			"K8S_AUTH_KUBECONFIG": kubeconfig,
 			"KUBECONFIG":          kubeconfig,
@@ -246,6 +272,7 @@ func (r *runner) Run(ident string, u *unstructured.Unstructured, kubeconfig stri
 		}
 	}
 
+	exitErr := make(chan error, 1)
 	go func() {
 		var dc *exec.Cmd
 		if r.isFinalizerRun(u) {
@@ -266,6 +293,7 @@ func (r *runner) Run(ident string, u *unstructured.Unstructured, kubeconfig stri
 		} else {
 			logger.Info("Ansible-runner exited successfully")
 		}
+		exitErr <- err
 
 		receiver.Close()
 		err = <-errChan
@@ -288,8 +316,13 @@ func (r *runner) Run(ident string, u *unstructured.Unstructured, kubeconfig stri
 
 	}()
 
+	var events <-chan eventapi.JobEvent = receiver.Events
+	if cacheKey != "" {
+		events = r.cache.record(cacheObj, cacheKey, &cachedRunResult{ident: ident, inputDir: &inputDir}, events, exitErr)
+	}
+
 	return &runResult{
-		events:   receiver.Events,
+		events:   events,
 		inputDir: &inputDir,
 		ident:    ident,
 	}, nil
@@ -351,7 +384,7 @@ func (r *runner) makeParameters(u *unstructured.Unstructured) map[string]interfa
 
 	parameters["ansible_operator_meta"] = map[string]string{"namespace": u.GetNamespace(), "name": u.GetName()}
 
-	objKey := escapeAnsibleKey(fmt.Sprintf("_%v_%v", r.GVK.Group, strings.ToLower(r.GVK.Kind)))
+	objKey := r.objectKey()
 	parameters[objKey] = u.Object
 
 	specKey := fmt.Sprintf("%s_spec", objKey)
@@ -368,6 +401,11 @@ func (r *runner) makeParameters(u *unstructured.Unstructured) map[string]interfa
 	return parameters
 }
 
+// objectKey returns the parameter key of the CR object, _<group_as_snake>_<kind>.
+func (r *runner) objectKey() string {
+	return escapeAnsibleKey(fmt.Sprintf("_%v_%v", r.GVK.Group, strings.ToLower(r.GVK.Kind)))
+}
+
 // markUnsafe recursively checks for string values and marks them unsafe.
 // for eg:
 //		spec:
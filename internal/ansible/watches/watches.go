@@ -54,6 +54,7 @@ type Watch struct {
 	SnakeCaseParameters         bool                      `yaml:"snakeCaseParameters"`
 	MarkUnsafe                  bool                      `yaml:"markUnsafe"`
 	Selector                    metav1.LabelSelector      `yaml:"selector"`
+	ResultCache                 *ResultCache              `yaml:"resultCache"`
 
 	// Not configurable via watches.yaml
 	MaxConcurrentReconciles int `yaml:"-"`
@@ -68,6 +69,15 @@ type Finalizer struct {
 	Vars     map[string]interface{} `yaml:"vars"`
 }
 
+// ResultCache - configures reuse of the result of a CR's last successful run by later reconciles of the
+// CR with identical inputs, such as those of the reconcile period. Dependent resources must not be watched.
+type ResultCache struct {
+	// TTL is how long a result is reused for.
+	TTL time.Duration `yaml:"ttl"`
+	// MaxEntries is the maximum number of results kept, one per CR, across all CRs of the watched GVK.
+	MaxEntries int `yaml:"maxEntries"`
+}
+
 // Default values for optional fields on Watch
 var (
 	blacklistDefault                   = []schema.GroupVersionKind{}
@@ -79,6 +89,7 @@ var (
 	snakeCaseParametersDefault         = true
 	markUnsafeDefault                  = false
 	selectorDefault                    = metav1.LabelSelector{}
+	resultCacheMaxEntriesDefault       = 100
 
 	// these are overridden by cmdline flags
 	maxConcurrentReconcilesDefault = runtime.NumCPU()
@@ -109,6 +120,11 @@ type tempLabelSelector struct {
 	MatchExpressions []tempRequirement `json:"matchExpressions,omitempty"`
 }
 
+type tempResultCache struct {
+	TTL        *metav1.Duration `yaml:"ttl"`
+	MaxEntries int              `yaml:"maxEntries"`
+}
+
 type tempRequirement struct {
 	Key      string                       `json:"key"`
 	Operator metav1.LabelSelectorOperator `json:"operator"`
@@ -133,6 +149,7 @@ type alias struct {
 	Blacklist                   []schema.GroupVersionKind `yaml:"blacklist,omitempty"`
 	Finalizer                   *Finalizer                `yaml:"finalizer"`
 	Selector                    tempLabelSelector         `yaml:"selector"`
+	ResultCache                 *tempResultCache          `yaml:"resultCache,omitempty"`
 }
 
 // buildWatch will build Watch based on the values parsed from alias
@@ -202,10 +219,26 @@ func (w *Watch) setValuesFromAlias(tmp alias) error {
 	}
 	w.addRolePlaybookPaths(wd)
 	w.Selector = parseLabelSelector(tmp.Selector)
+	w.ResultCache = parseResultCache(tmp.ResultCache)
 
 	return nil
 }
 
+// parseResultCache returns nil if tmp is nil, since result caching is opt-in.
+func parseResultCache(tmp *tempResultCache) *ResultCache {
+	if tmp == nil {
+		return nil
+	}
+	rc := &ResultCache{MaxEntries: tmp.MaxEntries}
+	if tmp.TTL != nil {
+		rc.TTL = tmp.TTL.Duration
+	}
+	if rc.MaxEntries == 0 {
+		rc.MaxEntries = resultCacheMaxEntriesDefault
+	}
+	return rc
+}
+
 // addRolePlaybookPaths will add the full path based on the current dir
 func (w *Watch) addRolePlaybookPaths(rootDir string) {
 	if len(w.Playbook) > 0 {
@@ -284,6 +317,7 @@ func getPossibleRolePaths(rootDir, path string) []string {
 // A Watch is considered valid if it:
 // - Specifies a valid path to a Role||Playbook
 // - If a Finalizer is non-nil, it must have a name + valid path to a Role||Playbook or Vars
+// - If a ResultCache is non-nil, it must have a positive TTL and max entries, and not watch dependent resources
 func (w *Watch) Validate() error {
 	err := verifyAnsiblePath(w.Playbook, w.Role)
 	if err != nil {
@@ -306,6 +340,19 @@ func (w *Watch) Validate() error {
 		}
 	}
 
+	if w.ResultCache != nil {
+		if w.ResultCache.TTL <= 0 || w.ResultCache.MaxEntries <= 0 {
+			err = fmt.Errorf("resultCache must have a positive ttl and maxEntries")
+			log.Error(err, fmt.Sprintf("Invalid resultCache for GVK: %v", w.GroupVersionKind.String()))
+			return err
+		}
+		if w.WatchDependentResources {
+			err = fmt.Errorf("resultCache requires watchDependentResources to be false")
+			log.Error(err, fmt.Sprintf("Invalid resultCache for GVK: %v", w.GroupVersionKind.String()))
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -382,6 +382,28 @@ func TestLoad(t *testing.T) { //nolint:gocyclo
 			},
 			ManageStatus: true,
 		},
+		Watch{
+			GroupVersionKind: schema.GroupVersionKind{
+				Version: "v1alpha1",
+				Group:   "app.example.com",
+				Kind:    "ResultCache",
+			},
+			Role:                    validTemplate.ValidRole,
+			ManageStatus:            true,
+			WatchDependentResources: false,
+			ResultCache:             &ResultCache{TTL: 10 * time.Minute, MaxEntries: resultCacheMaxEntriesDefault},
+		},
+		Watch{
+			GroupVersionKind: schema.GroupVersionKind{
+				Version: "v1alpha1",
+				Group:   "app.example.com",
+				Kind:    "ResultCacheMaxEntries",
+			},
+			Role:                    validTemplate.ValidRole,
+			ManageStatus:            true,
+			WatchDependentResources: false,
+			ResultCache:             &ResultCache{TTL: 30 * time.Second, MaxEntries: 5},
+		},
 	}
 
 	testCases := []struct {
@@ -459,6 +481,16 @@ func TestLoad(t *testing.T) { //nolint:gocyclo
 			path:        "testdata/invalid_status.yaml",
 			shouldError: true,
 		},
+		{
+			name:        "error invalid result cache",
+			path:        "testdata/invalid_result_cache.yaml",
+			shouldError: true,
+		},
+		{
+			name:        "error result cache with watched dependent resources",
+			path:        "testdata/invalid_result_cache_dependent_resources.yaml",
+			shouldError: true,
+		},
 		{
 			name:        "if collection env var is not set and collection is not installed to the default locations, fail",
 			path:        "testdata/invalid_collection.yaml",
@@ -563,6 +595,11 @@ func TestLoad(t *testing.T) { //nolint:gocyclo
 					}
 				}
 
+				if !reflect.DeepEqual(gotWatch.ResultCache, expectedWatch.ResultCache) {
+					t.Fatalf("Incorrect result cache GVK %s:\n\tgot %#v\n\texpected %#v", gvk,
+						gotWatch.ResultCache, expectedWatch.ResultCache)
+				}
+
 				if !reflect.DeepEqual(gotWatch.Selector, expectedWatch.Selector) {
 					t.Fatalf("Incorrect selector GVK %s:\n\tgot %s\n\texpected %s", gvk,
 						gotWatch.Selector, expectedWatch.Selector)
@@ -126,6 +126,18 @@ func run(cmd *cobra.Command, f *flags.Flags) {
 		os.Exit(1)
 	}
 
+	var manifestDiff *controller.ManifestDiffOptions
+	if f.ManifestDiffLogLevel >= 0 || f.ManifestDiffSummary != controller.ManifestDiffSummaryNone {
+		manifestDiff = &controller.ManifestDiffOptions{
+			LogLevel: f.ManifestDiffLogLevel,
+			Summary:  f.ManifestDiffSummary,
+		}
+		if err := manifestDiff.Validate(); err != nil {
+			log.Error(err, "invalid flags usage")
+			os.Exit(1)
+		}
+	}
+
 	// Set default manager options
 	options = f.ToManagerOptions(options)
 	if options.ClientBuilder == nil {
@@ -184,6 +196,8 @@ func run(cmd *cobra.Command, f *flags.Flags) {
 			WatchDependentResources: *w.WatchDependentResources,
 			OverrideValues:          w.OverrideValues,
 			MaxConcurrentReconciles: f.MaxConcurrentReconciles,
+			MaintenanceWindow:       w.MaintenanceWindow,
+			ManifestDiff:            manifestDiff,
 		})
 		if err != nil {
 			log.Error(err, "Failed to add manager factory to controller.")
@@ -17,6 +17,8 @@ package bundle
 import (
 	"github.com/spf13/cobra"
 
+	"github.com/operator-framework/operator-sdk/internal/cmd/operator-sdk/bundle/deps"
+	"github.com/operator-framework/operator-sdk/internal/cmd/operator-sdk/bundle/migratecsv"
 	"github.com/operator-framework/operator-sdk/internal/cmd/operator-sdk/bundle/validate"
 )
 
@@ -38,6 +40,8 @@ https://sdk.operatorframework.io/docs/olm-integration
 
 	cmd.AddCommand(
 		validate.NewCmd(),
+		deps.NewCmd(),
+		migratecsv.NewCmd(),
 	)
 	return cmd
 }
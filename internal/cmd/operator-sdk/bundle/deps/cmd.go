@@ -0,0 +1,111 @@
+// Copyright 2021 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deps
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const (
+	longHelp = `The 'operator-sdk bundle deps' command prints the dependencies an operator bundle declares
+in its metadata/dependencies.yaml file, which must be present in a cluster for the bundle to be installed.
+Each package dependency is printed with its version range, and each GVK dependency with its API version.
+A warning is logged for each dependency with an invalid format.
+
+More information about operator bundle dependencies:
+https://olm.operatorframework.io/docs/concepts/olm-architecture/dependency-resolution/
+
+NOTE: if inspecting an image, the image must exist in a remote registry, not just locally.
+`
+
+	examples = `To print the dependencies of a local bundle:
+
+  $ operator-sdk bundle deps ./bundle
+  TYPE           DEPENDENCY                              VERSION
+  olm.package    prometheus                              >0.27.0
+  olm.gvk        EtcdCluster.etcd.database.coreos.com    v1beta2
+
+To print the dependencies of a *pullable* bundle image as JSON:
+
+  $ operator-sdk bundle deps <some-registry>/<operator-bundle-name>:<tag> --output json
+`
+)
+
+const (
+	textOutput = "text"
+	jsonOutput = "json"
+)
+
+type bundleDepsCmd struct {
+	outputFormat string
+}
+
+// NewCmd returns a command that will print an operator bundle's dependencies.
+func NewCmd() *cobra.Command {
+	c := bundleDepsCmd{}
+	cmd := &cobra.Command{
+		Use:     "deps <bundle-dir-or-image>",
+		Short:   "Print an operator bundle's dependencies",
+		Long:    longHelp,
+		Example: examples,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := c.validate(args); err != nil {
+				return fmt.Errorf("invalid command args: %v", err)
+			}
+			if err := c.run(args[0]); err != nil {
+				log.Fatal(err)
+			}
+			return nil
+		},
+	}
+
+	c.addToFlagSet(cmd.Flags())
+
+	return cmd
+}
+
+func (c *bundleDepsCmd) addToFlagSet(fs *pflag.FlagSet) {
+	fs.StringVarP(&c.outputFormat, "output", "o", textOutput, "Output format. One of: [text, json]")
+}
+
+// validate verifies the command args
+func (c bundleDepsCmd) validate(args []string) error {
+	if len(args) != 1 {
+		return errors.New("an image tag or directory is a required argument")
+	}
+	if c.outputFormat != textOutput && c.outputFormat != jsonOutput {
+		return fmt.Errorf("invalid value for output flag: %v", c.outputFormat)
+	}
+	return nil
+}
+
+func (c bundleDepsCmd) run(bundleRaw string) error {
+	deps, err := getDependencies(bundleRaw)
+	if err != nil {
+		return err
+	}
+	for _, dep := range deps {
+		for _, warning := range dep.Warnings {
+			log.Warnf("Invalid %s dependency %s: %s", dep.Type, dep.name(), warning)
+		}
+	}
+	return printDependencies(os.Stdout, deps, c.outputFormat)
+}
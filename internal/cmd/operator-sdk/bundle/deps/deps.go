@@ -0,0 +1,213 @@
+// Copyright 2021 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deps
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/blang/semver/v4"
+	registrybundle "github.com/operator-framework/operator-registry/pkg/lib/bundle"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"sigs.k8s.io/yaml"
+
+	"github.com/operator-framework/operator-sdk/internal/flags"
+	registryutil "github.com/operator-framework/operator-sdk/internal/registry"
+)
+
+// Dependency types understood by OLM.
+const (
+	packageType = "olm.package"
+	gvkType     = "olm.gvk"
+	labelType   = "olm.label"
+)
+
+// dependency is a single entry of a bundle's dependencies file, flattened for output.
+type dependency struct {
+	Type string `json:"type"`
+	// PackageName and Version are set for package dependencies, where Version is a semver range.
+	PackageName string `json:"packageName,omitempty"`
+	// Group, Version, and Kind are set for GVK dependencies.
+	Group   string `json:"group,omitempty"`
+	Version string `json:"version,omitempty"`
+	Kind    string `json:"kind,omitempty"`
+	// Label is set for label dependencies.
+	Label string `json:"label,omitempty"`
+	// Warnings describe why the dependency's format is invalid, if it is.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// name returns a human-readable name for d.
+func (d dependency) name() string {
+	switch d.Type {
+	case packageType:
+		return d.PackageName
+	case gvkType:
+		if d.Group == "" {
+			return d.Kind
+		}
+		return d.Kind + "." + d.Group
+	case labelType:
+		return d.Label
+	}
+	return ""
+}
+
+// getDependencies returns the dependencies declared by the bundle in directory or image bundleRaw.
+func getDependencies(bundleRaw string) ([]dependency, error) {
+	bundleDir := bundleRaw
+	// Extract bundle image contents if bundle is inferred to be an image.
+	if _, err := os.Stat(bundleRaw); err != nil && errors.Is(err, os.ErrNotExist) {
+		if bundleDir, err = extractBundleImage(bundleRaw); err != nil {
+			return nil, err
+		}
+		defer func() {
+			if err := os.RemoveAll(bundleDir); err != nil {
+				log.Error(err)
+			}
+		}()
+	}
+
+	// The dependencies file is always next to the annotations file.
+	_, annotationsPath, err := registryutil.FindBundleMetadata(bundleDir)
+	if err != nil {
+		return nil, err
+	}
+	depsPath := filepath.Join(filepath.Dir(annotationsPath), registrybundle.DependenciesFile)
+	b, err := ioutil.ReadFile(depsPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	deps, err := parseDependencies(b)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing dependencies file %s: %v", depsPath, err)
+	}
+	return deps, nil
+}
+
+// parseDependencies parses the contents of a dependencies file. Dependencies with an invalid
+// format are returned with warnings, since other dependencies may still be useful to inspect.
+func parseDependencies(b []byte) ([]dependency, error) {
+	depsFile := struct {
+		Dependencies []struct {
+			Type  string          `json:"type"`
+			Value json.RawMessage `json:"value"`
+		} `json:"dependencies"`
+	}{}
+	if err := yaml.Unmarshal(b, &depsFile); err != nil {
+		return nil, err
+	}
+
+	deps := make([]dependency, 0, len(depsFile.Dependencies))
+	for _, d := range depsFile.Dependencies {
+		dep := dependency{Type: d.Type}
+		if len(d.Value) == 0 {
+			dep.Warnings = append(dep.Warnings, "value must be set")
+			deps = append(deps, dep)
+			continue
+		}
+
+		switch d.Type {
+		case packageType, gvkType, labelType:
+			if err := json.Unmarshal(d.Value, &dep); err != nil {
+				dep.Warnings = append(dep.Warnings, fmt.Sprintf("error parsing value: %v", err))
+				break
+			}
+			// Do not let the value overwrite the type, or smuggle in its own warnings.
+			dep.Type, dep.Warnings = d.Type, nil
+			dep.Warnings = validateDependency(dep)
+		default:
+			dep.Warnings = append(dep.Warnings, fmt.Sprintf("unknown dependency type %q", d.Type))
+		}
+		deps = append(deps, dep)
+	}
+	return deps, nil
+}
+
+// validateDependency returns warnings for each missing or invalid field of dep.
+func validateDependency(dep dependency) (warnings []string) {
+	switch dep.Type {
+	case packageType:
+		if dep.PackageName == "" {
+			warnings = append(warnings, "packageName must be set")
+		}
+		if dep.Version == "" {
+			warnings = append(warnings, "version must be set")
+		} else if _, err := semver.ParseRange(dep.Version); err != nil {
+			warnings = append(warnings, fmt.Sprintf("invalid version range %q: %v", dep.Version, err))
+		}
+	case gvkType:
+		// Group may be empty for core APIs.
+		if dep.Version == "" {
+			warnings = append(warnings, "version must be set")
+		}
+		if dep.Kind == "" {
+			warnings = append(warnings, "kind must be set")
+		}
+	case labelType:
+		if dep.Label == "" {
+			warnings = append(warnings, "label must be set")
+		}
+	}
+	return warnings
+}
+
+// printDependencies writes deps to w in outputFormat.
+func printDependencies(w io.Writer, deps []dependency, outputFormat string) error {
+	if outputFormat == jsonOutput {
+		if deps == nil {
+			deps = []dependency{}
+		}
+		b, err := json.MarshalIndent(deps, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(b))
+		return err
+	}
+
+	if len(deps) == 0 {
+		_, err := fmt.Fprintln(w, "No dependencies found")
+		return err
+	}
+	tw := tabwriter.NewWriter(w, 8, 4, 4, ' ', 0)
+	fmt.Fprintf(tw, "TYPE\tDEPENDENCY\tVERSION\n")
+	for _, dep := range deps {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", dep.Type, dep.name(), dep.Version)
+	}
+	return tw.Flush()
+}
+
+// extractBundleImage returns bundleImage's path on disk post-extraction.
+func extractBundleImage(bundleImage string) (string, error) {
+	// Discard bundle extraction logs unless user sets verbose mode.
+	logger := registryutil.DiscardLogger()
+	if viper.GetBool(flags.VerboseOpt) {
+		logger = log.WithFields(log.Fields{"bundle": bundleImage})
+	}
+	return registryutil.ExtractBundleImage(context.TODO(), logger, bundleImage, false)
+}
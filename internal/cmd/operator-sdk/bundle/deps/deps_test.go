@@ -0,0 +1,166 @@
+// Copyright 2021 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deps
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const validDependencies = `dependencies:
+- type: olm.package
+  value:
+    packageName: prometheus
+    version: ">0.27.0"
+- type: olm.gvk
+  value:
+    group: etcd.database.coreos.com
+    kind: EtcdCluster
+    version: v1beta2
+`
+
+var _ = Describe("Running a bundle deps command", func() {
+	Describe("NewCmd", func() {
+		It("builds and returns a cobra command", func() {
+			cmd := NewCmd()
+			Expect(cmd).NotTo(BeNil())
+
+			flag := cmd.Flags().Lookup("output")
+			Expect(flag).NotTo(BeNil())
+			Expect(flag.Shorthand).To(Equal("o"))
+			Expect(flag.DefValue).To(Equal(textOutput))
+		})
+	})
+
+	Describe("validate", func() {
+		var cmd bundleDepsCmd
+		BeforeEach(func() {
+			cmd = bundleDepsCmd{outputFormat: textOutput}
+		})
+
+		It("fails with no args", func() {
+			Expect(cmd.validate([]string{})).NotTo(Succeed())
+		})
+		It("fails with more than one arg", func() {
+			Expect(cmd.validate([]string{"a", "b"})).NotTo(Succeed())
+		})
+		It("fails with an invalid output format", func() {
+			cmd.outputFormat = "yaml"
+			Expect(cmd.validate([]string{"a"})).NotTo(Succeed())
+		})
+		It("succeeds with one arg and a valid output format", func() {
+			Expect(cmd.validate([]string{"a"})).To(Succeed())
+			cmd.outputFormat = jsonOutput
+			Expect(cmd.validate([]string{"a"})).To(Succeed())
+		})
+	})
+
+	Describe("parseDependencies", func() {
+		It("parses valid package and GVK dependencies", func() {
+			deps, err := parseDependencies([]byte(validDependencies))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deps).To(Equal([]dependency{
+				{Type: packageType, PackageName: "prometheus", Version: ">0.27.0"},
+				{Type: gvkType, Group: "etcd.database.coreos.com", Kind: "EtcdCluster", Version: "v1beta2"},
+			}))
+		})
+		It("warns about dependencies with an invalid format", func() {
+			deps, err := parseDependencies([]byte(`dependencies:
+- type: olm.package
+  value:
+    packageName: prometheus
+    version: not-a-range
+- type: olm.gvk
+  value:
+    group: etcd.database.coreos.com
+- type: olm.unknown
+  value:
+    foo: bar
+- type: olm.label
+`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deps).To(HaveLen(4))
+			Expect(deps[0].Warnings).To(HaveLen(1))
+			Expect(deps[1].Warnings).To(HaveLen(2))
+			Expect(deps[2].Warnings).To(HaveLen(1))
+			Expect(deps[3].Warnings).To(HaveLen(1))
+		})
+		It("fails on invalid YAML", func() {
+			_, err := parseDependencies([]byte("dependencies: {"))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("getDependencies", func() {
+		var bundleDir string
+
+		BeforeEach(func() {
+			var err error
+			bundleDir, err = ioutil.TempDir("", "bundle-deps-")
+			Expect(err).NotTo(HaveOccurred())
+			metadataDir := filepath.Join(bundleDir, "metadata")
+			Expect(os.MkdirAll(metadataDir, 0755)).To(Succeed())
+			annotations := "annotations:\n  operators.operatorframework.io.bundle.package.v1: memcached-operator\n"
+			Expect(ioutil.WriteFile(filepath.Join(metadataDir, "annotations.yaml"), []byte(annotations), 0644)).To(Succeed())
+		})
+		AfterEach(func() {
+			Expect(os.RemoveAll(bundleDir)).To(Succeed())
+		})
+
+		It("returns no dependencies if the bundle has no dependencies file", func() {
+			deps, err := getDependencies(bundleDir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deps).To(BeEmpty())
+		})
+		It("returns dependencies from the bundle's dependencies file", func() {
+			depsPath := filepath.Join(bundleDir, "metadata", "dependencies.yaml")
+			Expect(ioutil.WriteFile(depsPath, []byte(validDependencies), 0644)).To(Succeed())
+			deps, err := getDependencies(bundleDir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deps).To(HaveLen(2))
+		})
+	})
+
+	Describe("printDependencies", func() {
+		var deps []dependency
+		BeforeEach(func() {
+			var err error
+			deps, err = parseDependencies([]byte(validDependencies))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("prints a table", func() {
+			out := &bytes.Buffer{}
+			Expect(printDependencies(out, deps, textOutput)).To(Succeed())
+			Expect(out.String()).To(ContainSubstring("olm.package    prometheus"))
+			Expect(out.String()).To(ContainSubstring("EtcdCluster.etcd.database.coreos.com    v1beta2"))
+		})
+		It("prints JSON", func() {
+			out := &bytes.Buffer{}
+			Expect(printDependencies(out, deps, jsonOutput)).To(Succeed())
+			Expect(out.String()).To(ContainSubstring(`"packageName": "prometheus"`))
+		})
+		It("prints an empty JSON list with no dependencies", func() {
+			out := &bytes.Buffer{}
+			Expect(printDependencies(out, nil, jsonOutput)).To(Succeed())
+			Expect(out.String()).To(Equal("[]\n"))
+		})
+	})
+})
@@ -0,0 +1,127 @@
+// Copyright 2021 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migratecsv
+
+import (
+	"errors"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const (
+	longHelp = `The 'operator-sdk bundle migrate-csv' command upgrades the ClusterServiceVersion (CSV) in a bundle
+directory to the current CSV schema, operators.coreos.com/v1alpha1, and rewrites the CSV's manifest in place.
+The CSV is read from the directory's manifests/ subdirectory if it exists, otherwise from the directory itself.
+
+Deprecated fields are moved or renamed to their current equivalents:
+
+  - apiVersion app.coreos.com/v1alpha1 and kind ClusterServiceVersion-v1, used by CSVs written for ALM,
+    are changed to operators.coreos.com/v1alpha1 and ClusterServiceVersion.
+  - A single spec.icon object is changed to a list containing that icon.
+  - A spec.provider string is changed to an object with that name.
+  - A comma-separated spec.keywords string is changed to a list of keywords.
+
+A warning is logged for each field that cannot be migrated automatically and must be updated by hand,
+ex. a missing spec.installModes, or a field that is not part of the current schema. All other fields,
+including all annotations, are preserved as-is.
+`
+
+	examples = `To migrate the CSV of a local bundle:
+
+  $ operator-sdk bundle migrate-csv ./bundle
+  INFO[0000] Migrated apiVersion "app.coreos.com/v1alpha1" to "operators.coreos.com/v1alpha1"
+  INFO[0000] Migrated kind "ClusterServiceVersion-v1" to "ClusterServiceVersion"
+  WARN[0000] spec.installModes is not set, add the install modes your operator supports
+  INFO[0000] Wrote migrated CSV to bundle/manifests/memcached-operator.clusterserviceversion.yaml
+
+To print the migrated CSV without modifying the bundle:
+
+  $ operator-sdk bundle migrate-csv ./bundle --dry-run
+`
+)
+
+type bundleMigrateCSVCmd struct {
+	dryRun bool
+}
+
+// NewCmd returns a command that will migrate an operator bundle's CSV to the current schema.
+func NewCmd() *cobra.Command {
+	c := bundleMigrateCSVCmd{}
+	cmd := &cobra.Command{
+		Use:     "migrate-csv <bundle-dir>",
+		Short:   "Migrate an operator bundle's CSV to the current schema",
+		Long:    longHelp,
+		Example: examples,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := c.validate(args); err != nil {
+				return fmt.Errorf("invalid command args: %v", err)
+			}
+			if err := c.run(args[0]); err != nil {
+				log.Fatal(err)
+			}
+			return nil
+		},
+	}
+
+	c.addToFlagSet(cmd.Flags())
+
+	return cmd
+}
+
+func (c *bundleMigrateCSVCmd) addToFlagSet(fs *pflag.FlagSet) {
+	fs.BoolVar(&c.dryRun, "dry-run", false, "Print the migrated CSV to stdout instead of writing it to the bundle")
+}
+
+// validate verifies the command args
+func (c bundleMigrateCSVCmd) validate(args []string) error {
+	if len(args) != 1 {
+		return errors.New("a bundle directory is a required argument")
+	}
+	return nil
+}
+
+func (c bundleMigrateCSVCmd) run(bundleDir string) error {
+	csvFile, err := findCSV(bundleDir)
+	if err != nil {
+		return err
+	}
+
+	changes, warnings, err := migrateCSV(csvFile.csv)
+	if err != nil {
+		return fmt.Errorf("error migrating CSV %s: %v", csvFile.path, err)
+	}
+	for _, change := range changes {
+		log.Infof("Migrated %s", change)
+	}
+	for _, warning := range warnings {
+		log.Warn(warning)
+	}
+
+	if c.dryRun {
+		return csvFile.print()
+	}
+	if len(changes) == 0 {
+		log.Infof("CSV %s is already up to date", csvFile.path)
+		return nil
+	}
+	if err := csvFile.write(); err != nil {
+		return err
+	}
+	log.Infof("Wrote migrated CSV to %s", csvFile.path)
+	return nil
+}
@@ -0,0 +1,277 @@
+// Copyright 2021 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migratecsv
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	registrybundle "github.com/operator-framework/operator-registry/pkg/lib/bundle"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	"github.com/operator-framework/operator-sdk/internal/util/k8sutil"
+)
+
+const (
+	// legacyAPIVersion and legacyKind were used by CSVs written for ALM, OLM's predecessor.
+	legacyAPIVersion = "app.coreos.com/v1alpha1"
+	legacyKind       = "ClusterServiceVersion-v1"
+)
+
+// csvAPIVersion is the API version of the current CSV schema.
+var csvAPIVersion = operatorsv1alpha1.SchemeGroupVersion.String()
+
+// csvFile is a CSV manifest read from a bundle.
+type csvFile struct {
+	path string
+	mode os.FileMode
+	csv  *unstructured.Unstructured
+}
+
+// findCSV returns the CSV in bundleDir's manifests directory, or in bundleDir if it has no manifests directory.
+func findCSV(bundleDir string) (*csvFile, error) {
+	manifestsDir := filepath.Join(bundleDir, registrybundle.ManifestsDir)
+	if info, err := os.Stat(manifestsDir); err != nil || !info.IsDir() {
+		manifestsDir = bundleDir
+	}
+	infos, err := ioutil.ReadDir(manifestsDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var found *csvFile
+	for _, info := range infos {
+		switch filepath.Ext(info.Name()) {
+		case ".yaml", ".yml", ".json":
+		default:
+			continue
+		}
+		if info.IsDir() {
+			continue
+		}
+		path := filepath.Join(manifestsDir, info.Name())
+		csv, err := readCSV(path)
+		if err != nil {
+			return nil, err
+		}
+		if csv == nil {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("more than one CSV found in %s: %s and %s", manifestsDir, found.path, path)
+		}
+		found = &csvFile{path: path, mode: info.Mode(), csv: csv}
+	}
+	if found == nil {
+		return nil, fmt.Errorf("no CSV found in %s", manifestsDir)
+	}
+	return found, nil
+}
+
+// readCSV returns the CSV in the manifest file at path, or nil if the file does not contain a CSV.
+func readCSV(path string) (*unstructured.Unstructured, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var csv *unstructured.Unstructured
+	docs := 0
+	scanner := k8sutil.NewYAMLScanner(bytes.NewReader(b))
+	for scanner.Scan() {
+		docs++
+		u := &unstructured.Unstructured{}
+		if err := yaml.Unmarshal(scanner.Bytes(), &u.Object); err != nil {
+			return nil, fmt.Errorf("error parsing %s: %v", path, err)
+		}
+		if kind := u.GetKind(); kind == operatorsv1alpha1.ClusterServiceVersionKind || kind == legacyKind {
+			csv = u
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading %s: %v", path, err)
+	}
+	// The file is rewritten with only the migrated CSV, so other manifests in it would be lost.
+	if csv != nil && docs > 1 {
+		return nil, fmt.Errorf("CSV file %s contains other manifests, move the CSV to its own file", path)
+	}
+	return csv, nil
+}
+
+// write replaces the contents of f's manifest file with f's CSV.
+func (f csvFile) write() error {
+	b, err := yaml.Marshal(f.csv.Object)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(f.path, b, f.mode)
+}
+
+// print writes f's CSV to stdout.
+func (f csvFile) print() error {
+	b, err := yaml.Marshal(f.csv.Object)
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(b)
+	return err
+}
+
+// migration upgrades a deprecated part of a CSV in place, returning a description of each change made
+// and a warning for each deprecated field that must be migrated by hand.
+type migration func(csv *unstructured.Unstructured) (changes, warnings []string, err error)
+
+var migrations = []migration{
+	migrateTypeMeta,
+	migrateIcon,
+	migrateProvider,
+	migrateKeywords,
+	checkInstallModes,
+	checkInstallStrategy,
+}
+
+// migrateCSV runs all migrations on csv, then checks that csv matches the current schema.
+// Any field not touched by a migration, such as an annotation, is left as-is.
+func migrateCSV(csv *unstructured.Unstructured) (changes, warnings []string, err error) {
+	for _, migrate := range migrations {
+		c, w, err := migrate(csv)
+		if err != nil {
+			return nil, nil, err
+		}
+		changes = append(changes, c...)
+		warnings = append(warnings, w...)
+	}
+	w, err := checkSchema(csv)
+	if err != nil {
+		return nil, nil, err
+	}
+	return changes, append(warnings, w...), nil
+}
+
+// getSpec returns csv's spec, or an empty map if csv has no spec.
+func getSpec(csv *unstructured.Unstructured) map[string]interface{} {
+	if spec, isMap := csv.Object["spec"].(map[string]interface{}); isMap {
+		return spec
+	}
+	return map[string]interface{}{}
+}
+
+// migrateTypeMeta replaces a legacy apiVersion and kind with the current ones.
+func migrateTypeMeta(csv *unstructured.Unstructured) (changes, warnings []string, err error) {
+	switch apiVersion := csv.GetAPIVersion(); apiVersion {
+	case csvAPIVersion:
+	case legacyAPIVersion:
+		csv.SetAPIVersion(csvAPIVersion)
+		changes = append(changes, fmt.Sprintf("apiVersion %q to %q", apiVersion, csvAPIVersion))
+	default:
+		return nil, nil, fmt.Errorf("unsupported CSV apiVersion %q", apiVersion)
+	}
+	if kind := csv.GetKind(); kind == legacyKind {
+		csv.SetKind(operatorsv1alpha1.ClusterServiceVersionKind)
+		changes = append(changes, fmt.Sprintf("kind %q to %q", kind, operatorsv1alpha1.ClusterServiceVersionKind))
+	}
+	return changes, nil, nil
+}
+
+// migrateIcon replaces a single icon object with a list containing it.
+func migrateIcon(csv *unstructured.Unstructured) (changes, warnings []string, err error) {
+	icon, found := getSpec(csv)["icon"]
+	if !found {
+		return nil, nil, nil
+	}
+	if obj, isObj := icon.(map[string]interface{}); isObj {
+		if err := unstructured.SetNestedSlice(csv.Object, []interface{}{obj}, "spec", "icon"); err != nil {
+			return nil, nil, err
+		}
+		changes = append(changes, "spec.icon object to a list of icons")
+	}
+	return changes, nil, nil
+}
+
+// migrateProvider replaces a provider name string with a provider object.
+func migrateProvider(csv *unstructured.Unstructured) (changes, warnings []string, err error) {
+	if name, isString := getSpec(csv)["provider"].(string); isString {
+		provider := map[string]interface{}{"name": name}
+		if err := unstructured.SetNestedMap(csv.Object, provider, "spec", "provider"); err != nil {
+			return nil, nil, err
+		}
+		changes = append(changes, fmt.Sprintf("spec.provider string %q to spec.provider.name", name))
+	}
+	return changes, nil, nil
+}
+
+// migrateKeywords replaces a comma-separated keywords string with a list of keywords.
+func migrateKeywords(csv *unstructured.Unstructured) (changes, warnings []string, err error) {
+	if value, isString := getSpec(csv)["keywords"].(string); isString {
+		keywords := []interface{}{}
+		for _, keyword := range strings.Split(value, ",") {
+			if keyword = strings.TrimSpace(keyword); keyword != "" {
+				keywords = append(keywords, keyword)
+			}
+		}
+		if err := unstructured.SetNestedSlice(csv.Object, keywords, "spec", "keywords"); err != nil {
+			return nil, nil, err
+		}
+		changes = append(changes, fmt.Sprintf("spec.keywords string %q to a list of keywords", value))
+	}
+	return changes, nil, nil
+}
+
+// checkInstallModes warns if no install modes are set, since which modes an operator supports cannot be inferred.
+func checkInstallModes(csv *unstructured.Unstructured) (changes, warnings []string, err error) {
+	if _, found := getSpec(csv)["installModes"]; !found {
+		warnings = append(warnings, "spec.installModes is not set, add the install modes your operator supports")
+	}
+	return nil, warnings, nil
+}
+
+// checkInstallStrategy warns if the install strategy is not one OLM supports.
+func checkInstallStrategy(csv *unstructured.Unstructured) (changes, warnings []string, err error) {
+	strategy, _, err := unstructured.NestedString(csv.Object, "spec", "install", "strategy")
+	if err != nil {
+		return nil, nil, err
+	}
+	if strategy != operatorsv1alpha1.InstallStrategyNameDeployment {
+		warnings = append(warnings, fmt.Sprintf("spec.install.strategy %q is not supported, migrate it to %q",
+			strategy, operatorsv1alpha1.InstallStrategyNameDeployment))
+	}
+	return nil, warnings, nil
+}
+
+// checkSchema returns an error if csv's fields have types that do not match the current schema,
+// or a warning if csv contains a field that is not in the current schema.
+func checkSchema(csv *unstructured.Unstructured) (warnings []string, err error) {
+	b, err := json.Marshal(csv.Object)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &operatorsv1alpha1.ClusterServiceVersion{}); err != nil {
+		return nil, fmt.Errorf("CSV does not match the current schema: %v", err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&operatorsv1alpha1.ClusterServiceVersion{}); err != nil {
+		warnings = append(warnings, fmt.Sprintf("CSV contains a field that is not in the current schema, "+
+			"migrate or remove it by hand: %v", err))
+	}
+	return warnings, nil
+}
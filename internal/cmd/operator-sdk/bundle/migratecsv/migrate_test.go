@@ -0,0 +1,201 @@
+// Copyright 2021 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migratecsv
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+const legacyCSV = `apiVersion: app.coreos.com/v1alpha1
+kind: ClusterServiceVersion-v1
+metadata:
+  name: memcached-operator.v0.0.1
+  annotations:
+    alm-examples: '[]'
+    tectonic-visibility: ocs
+spec:
+  displayName: Memcached Operator
+  version: 0.0.1
+  icon:
+    base64data: ""
+    mediatype: image/png
+  provider: Example
+  keywords: memcached, cache,
+  install:
+    strategy: deployment
+    spec:
+      deployments: []
+`
+
+const currentCSV = `apiVersion: operators.coreos.com/v1alpha1
+kind: ClusterServiceVersion
+metadata:
+  name: memcached-operator.v0.0.1
+spec:
+  displayName: Memcached Operator
+  version: 0.0.1
+  installModes:
+  - type: AllNamespaces
+    supported: true
+  install:
+    strategy: deployment
+    spec:
+      deployments: []
+`
+
+func parseCSV(manifest string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	ExpectWithOffset(1, yaml.Unmarshal([]byte(manifest), &u.Object)).To(Succeed())
+	return u
+}
+
+var _ = Describe("Migrating a CSV", func() {
+	Describe("migrateCSV", func() {
+		It("migrates deprecated fields and preserves annotations", func() {
+			csv := parseCSV(legacyCSV)
+			changes, warnings, err := migrateCSV(csv)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changes).To(HaveLen(5))
+			Expect(warnings).To(ConsistOf(ContainSubstring("spec.installModes")))
+
+			Expect(csv.GetAPIVersion()).To(Equal("operators.coreos.com/v1alpha1"))
+			Expect(csv.GetKind()).To(Equal("ClusterServiceVersion"))
+			Expect(csv.GetAnnotations()).To(Equal(map[string]string{
+				"alm-examples":        "[]",
+				"tectonic-visibility": "ocs",
+			}))
+
+			icons, _, err := unstructured.NestedSlice(csv.Object, "spec", "icon")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(icons).To(HaveLen(1))
+			provider, _, err := unstructured.NestedString(csv.Object, "spec", "provider", "name")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(provider).To(Equal("Example"))
+			keywords, _, err := unstructured.NestedStringSlice(csv.Object, "spec", "keywords")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keywords).To(Equal([]string{"memcached", "cache"}))
+		})
+
+		It("makes no changes to a current CSV", func() {
+			csv := parseCSV(currentCSV)
+			changes, warnings, err := migrateCSV(csv)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changes).To(BeEmpty())
+			Expect(warnings).To(BeEmpty())
+			Expect(csv.Object).To(Equal(parseCSV(currentCSV).Object))
+		})
+
+		It("warns about fields that are not in the current schema", func() {
+			csv := parseCSV(currentCSV)
+			Expect(unstructured.SetNestedField(csv.Object, "v1", "spec", "legacyField")).To(Succeed())
+			_, warnings, err := migrateCSV(csv)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf(ContainSubstring("legacyField")))
+			Expect(csv.Object["spec"]).To(HaveKey("legacyField"))
+		})
+
+		It("warns about unsupported install strategies", func() {
+			csv := parseCSV(currentCSV)
+			Expect(unstructured.SetNestedField(csv.Object, "helm", "spec", "install", "strategy")).To(Succeed())
+			_, warnings, err := migrateCSV(csv)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf(ContainSubstring("spec.install.strategy")))
+		})
+
+		It("fails for an unknown apiVersion", func() {
+			csv := parseCSV(currentCSV)
+			csv.SetAPIVersion("operators.coreos.com/v2")
+			_, _, err := migrateCSV(csv)
+			Expect(err).To(MatchError(ContainSubstring("unsupported CSV apiVersion")))
+		})
+
+		It("fails if a field does not match the current schema", func() {
+			csv := parseCSV(currentCSV)
+			Expect(unstructured.SetNestedField(csv.Object, int64(1), "spec", "displayName")).To(Succeed())
+			_, _, err := migrateCSV(csv)
+			Expect(err).To(MatchError(ContainSubstring("does not match the current schema")))
+		})
+	})
+
+	Describe("findCSV", func() {
+		var bundleDir, manifestsDir string
+
+		BeforeEach(func() {
+			var err error
+			bundleDir, err = ioutil.TempDir("", "migrate-csv")
+			Expect(err).NotTo(HaveOccurred())
+			manifestsDir = filepath.Join(bundleDir, "manifests")
+			Expect(os.Mkdir(manifestsDir, 0755)).To(Succeed())
+			Expect(ioutil.WriteFile(filepath.Join(manifestsDir, "service.yaml"),
+				[]byte("apiVersion: v1\nkind: Service\nmetadata:\n  name: metrics\n"), 0644)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			Expect(os.RemoveAll(bundleDir)).To(Succeed())
+		})
+
+		It("finds a legacy CSV in the manifests directory and writes it back", func() {
+			path := filepath.Join(manifestsDir, "memcached-operator.clusterserviceversion.yaml")
+			Expect(ioutil.WriteFile(path, []byte(legacyCSV), 0644)).To(Succeed())
+
+			f, err := findCSV(bundleDir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(f.path).To(Equal(path))
+			Expect(f.csv.GetName()).To(Equal("memcached-operator.v0.0.1"))
+
+			_, _, err = migrateCSV(f.csv)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(f.write()).To(Succeed())
+			written, err := readCSV(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(written.GetKind()).To(Equal("ClusterServiceVersion"))
+			Expect(written.GetAnnotations()).To(HaveKeyWithValue("tectonic-visibility", "ocs"))
+		})
+
+		It("finds a CSV in a directory without a manifests directory", func() {
+			Expect(ioutil.WriteFile(filepath.Join(bundleDir, "csv.yaml"), []byte(currentCSV), 0644)).To(Succeed())
+			Expect(os.RemoveAll(manifestsDir)).To(Succeed())
+			f, err := findCSV(bundleDir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(f.path).To(Equal(filepath.Join(bundleDir, "csv.yaml")))
+		})
+
+		It("fails if there is no CSV", func() {
+			_, err := findCSV(bundleDir)
+			Expect(err).To(MatchError(ContainSubstring("no CSV found")))
+		})
+
+		It("fails if there is more than one CSV", func() {
+			Expect(ioutil.WriteFile(filepath.Join(manifestsDir, "a.yaml"), []byte(currentCSV), 0644)).To(Succeed())
+			Expect(ioutil.WriteFile(filepath.Join(manifestsDir, "b.yaml"), []byte(legacyCSV), 0644)).To(Succeed())
+			_, err := findCSV(bundleDir)
+			Expect(err).To(MatchError(ContainSubstring("more than one CSV")))
+		})
+
+		It("fails if the CSV file contains other manifests", func() {
+			Expect(ioutil.WriteFile(filepath.Join(manifestsDir, "csv.yaml"),
+				[]byte(currentCSV+"---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm\n"), 0644)).To(Succeed())
+			_, err := findCSV(bundleDir)
+			Expect(err).To(MatchError(ContainSubstring("contains other manifests")))
+		})
+	})
+})
@@ -0,0 +1,146 @@
+// Copyright 2021 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"strings"
+
+	apimanifests "github.com/operator-framework/api/pkg/manifests"
+	apierrors "github.com/operator-framework/api/pkg/validation/errors"
+	interfaces "github.com/operator-framework/api/pkg/validation/interfaces"
+	registrybundle "github.com/operator-framework/operator-registry/pkg/lib/bundle"
+
+	internalregistry "github.com/operator-framework/operator-sdk/internal/registry"
+)
+
+const (
+	// channelRegexKey is the --optional-values key used to override defaultChannelRegex.
+	channelRegexKey = "channel-regex"
+	// channelRegexFileKey is the --optional-values key of a file containing the regex to override
+	// defaultChannelRegex with. --optional-values splits its value on commas, so a regex containing
+	// a comma, ex. "[0-9]{1,2}", must be read from a file.
+	channelRegexFileKey = "channel-regex-file"
+	// defaultChannelRegex matches the channel names "stable", "candidate", "fast", or "x.y".
+	defaultChannelRegex = `stable|candidate|fast|[0-9]+\.[0-9]+`
+)
+
+// channelNamingValidator checks that all channels in a bundle's metadata match a regular expression,
+// and that the default channel, if set, is one of those channels.
+var channelNamingValidator interfaces.Validator = interfaces.ValidatorFunc(validateChannelNaming)
+
+func validateChannelNaming(objs ...interface{}) (results []apierrors.ManifestResult) {
+	var (
+		bundle         *apimanifests.Bundle
+		metadata       internalregistry.Labels
+		optionalValues map[string]string
+	)
+	for _, obj := range objs {
+		switch v := obj.(type) {
+		case *apimanifests.Bundle:
+			bundle = v
+		case internalregistry.Labels:
+			metadata = v
+		case map[string]string:
+			optionalValues = v
+		}
+	}
+
+	result := apierrors.ManifestResult{}
+	if bundle != nil {
+		result.Name = bundle.Name
+	}
+	result.Add(checkChannelNames(metadata, optionalValues)...)
+
+	return append(results, result)
+}
+
+// checkChannelNames returns errors for all channels in metadata that do not fully match the regular expression
+// set by optionalValues[channelRegexKey] or read from optionalValues[channelRegexFileKey],
+// or defaultChannelRegex if neither is set.
+func checkChannelNames(metadata internalregistry.Labels, optionalValues map[string]string) (errs []apierrors.Error) {
+	expr, err := channelRegex(optionalValues)
+	if err != nil {
+		return append(errs, apierrors.ErrFailedValidation(err.Error(), expr))
+	}
+	// Channel names must match the entire expression, not a substring of it.
+	re, err := regexp.Compile(fmt.Sprintf("^(?:%s)$", expr))
+	if err != nil {
+		return append(errs, apierrors.ErrFailedValidation(fmt.Sprintf("invalid %s %q: %v", channelRegexKey, expr, err), expr))
+	}
+
+	if len(metadata) == 0 {
+		return append(errs, apierrors.ErrInvalidBundle("bundle metadata not found", ""))
+	}
+
+	var channels []string
+	for _, channel := range strings.Split(metadata[registrybundle.ChannelsLabel], ",") {
+		if channel = strings.TrimSpace(channel); channel != "" {
+			channels = append(channels, channel)
+		}
+	}
+	if len(channels) == 0 {
+		return append(errs, apierrors.ErrInvalidBundle(
+			fmt.Sprintf("no channels found in metadata label %s", registrybundle.ChannelsLabel), ""))
+	}
+
+	for _, channel := range channels {
+		if !re.MatchString(channel) {
+			errs = append(errs, apierrors.ErrInvalidBundle(
+				fmt.Sprintf("channel name %q does not match %q", channel, expr), channel))
+		}
+	}
+
+	// An empty default channel is treated as unset.
+	if defaultChannel := metadata[registrybundle.ChannelDefaultLabel]; defaultChannel != "" {
+		if !containsString(channels, defaultChannel) {
+			errs = append(errs, apierrors.ErrInvalidBundle(
+				fmt.Sprintf("default channel %q is not one of the bundle's channels %q", defaultChannel, channels),
+				defaultChannel))
+		}
+	}
+
+	return errs
+}
+
+// channelRegex returns the channel name regex set in optionalValues, or defaultChannelRegex if none is set.
+func channelRegex(optionalValues map[string]string) (string, error) {
+	expr, hasExpr := optionalValues[channelRegexKey]
+	path, hasPath := optionalValues[channelRegexFileKey]
+	switch {
+	case hasExpr && hasPath:
+		return "", fmt.Errorf("only one of %s and %s may be set", channelRegexKey, channelRegexFileKey)
+	case hasExpr:
+		return expr, nil
+	case hasPath:
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("error reading %s %q: %v", channelRegexFileKey, path, err)
+		}
+		return strings.TrimSpace(string(b)), nil
+	}
+	return defaultChannelRegex, nil
+}
+
+func containsString(strs []string, s string) bool {
+	for _, str := range strs {
+		if str == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,107 @@
+// Copyright 2021 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	apimanifests "github.com/operator-framework/api/pkg/manifests"
+	registrybundle "github.com/operator-framework/operator-registry/pkg/lib/bundle"
+	"k8s.io/apimachinery/pkg/labels"
+
+	internalregistry "github.com/operator-framework/operator-sdk/internal/registry"
+)
+
+var _ = Describe("Validating channel naming", func() {
+	var metadata internalregistry.Labels
+
+	BeforeEach(func() {
+		metadata = internalregistry.Labels{
+			registrybundle.ChannelsLabel:       "stable,1.2",
+			registrybundle.ChannelDefaultLabel: "stable",
+		}
+	})
+
+	Describe("checkChannelNames", func() {
+		It("returns no errors for conforming channels with the default regex", func() {
+			Expect(checkChannelNames(metadata, nil)).To(BeEmpty())
+		})
+		It("returns an error for each non-conforming channel", func() {
+			metadata[registrybundle.ChannelsLabel] = "stable,alpha,stable-v1"
+			Expect(checkChannelNames(metadata, nil)).To(HaveLen(2))
+		})
+		It("uses the regex set in optional values", func() {
+			metadata[registrybundle.ChannelsLabel] = "stable,alpha"
+			optionalValues := map[string]string{channelRegexKey: "stable|alpha"}
+			Expect(checkChannelNames(metadata, optionalValues)).To(BeEmpty())
+		})
+		It("reads the regex from the file set in optional values", func() {
+			dir, err := ioutil.TempDir("", "channel-regex")
+			Expect(err).NotTo(HaveOccurred())
+			defer os.RemoveAll(dir)
+			path := filepath.Join(dir, "channel-regex.txt")
+			Expect(ioutil.WriteFile(path, []byte("stable|v[0-9]{1,2}\n"), 0644)).To(Succeed())
+
+			metadata[registrybundle.ChannelsLabel] = "stable,v12,v123"
+			optionalValues := map[string]string{channelRegexFileKey: path}
+			Expect(checkChannelNames(metadata, optionalValues)).To(HaveLen(1))
+		})
+		It("returns an error if the regex file cannot be read", func() {
+			optionalValues := map[string]string{channelRegexFileKey: "does-not-exist.txt"}
+			Expect(checkChannelNames(metadata, optionalValues)).To(HaveLen(1))
+		})
+		It("returns an error if both a regex and a regex file are set", func() {
+			optionalValues := map[string]string{channelRegexKey: "stable", channelRegexFileKey: "channel-regex.txt"}
+			Expect(checkChannelNames(metadata, optionalValues)).To(HaveLen(1))
+		})
+		It("returns an error for an invalid regex", func() {
+			optionalValues := map[string]string{channelRegexKey: "stable("}
+			Expect(checkChannelNames(metadata, optionalValues)).To(HaveLen(1))
+		})
+		It("returns an error if the default channel is not a bundle channel", func() {
+			metadata[registrybundle.ChannelDefaultLabel] = "fast"
+			Expect(checkChannelNames(metadata, nil)).To(HaveLen(1))
+		})
+		It("treats an empty default channel as unset", func() {
+			metadata[registrybundle.ChannelDefaultLabel] = ""
+			Expect(checkChannelNames(metadata, nil)).To(BeEmpty())
+		})
+		It("returns an error if there are no channels", func() {
+			delete(metadata, registrybundle.ChannelsLabel)
+			Expect(checkChannelNames(metadata, nil)).To(HaveLen(1))
+		})
+		It("returns an error if there is no metadata", func() {
+			Expect(checkChannelNames(nil, nil)).To(HaveLen(1))
+		})
+	})
+
+	Describe("run", func() {
+		It("passes bundle metadata to the validator", func() {
+			vals := validators{{Validator: channelNamingValidator, labels: map[string]string{nameKey: "channel-naming"}}}
+			sel := labels.SelectorFromSet(map[string]string{nameKey: "channel-naming"})
+
+			metadata[registrybundle.ChannelsLabel] = "alpha"
+			metadata[registrybundle.ChannelDefaultLabel] = "alpha"
+			results := vals.run(&apimanifests.Bundle{Name: "test"}, metadata, sel, nil)
+			Expect(results).To(HaveLen(1))
+			Expect(results[0].Name).To(Equal("test"))
+			Expect(results[0].Errors).To(HaveLen(1))
+		})
+	})
+})
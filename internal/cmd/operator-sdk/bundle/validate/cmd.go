@@ -65,9 +65,10 @@ To build and validate a *pullable* bundle image:
 To list and run optional validators, which are specified by a label selector:
 
   $ operator-sdk bundle validate --list-optional
-  NAME           LABELS                     DESCRIPTION
-  operatorhub    name=operatorhub           OperatorHub.io metadata validation
-                 suite=operatorframework
+  NAME              LABELS                     DESCRIPTION
+  operatorhub       name=operatorhub           OperatorHub.io metadata validation
+                    suite=operatorframework
+  channel-naming    name=channel-naming        Channel names match a regular expression set by --optional-values=channel-regex=<regex> or channel-regex-file=<path>
 
 To validate a bundle against the entire suite of validators for Operator Framework, in addition to required bundle validators:
 	
@@ -76,6 +77,17 @@ To validate a bundle against the entire suite of validators for Operator Framewo
 To validate a bundle against the validator for operatorhub.io specifically, in addition to required bundle validators:
 	
   $ operator-sdk bundle validate ./bundle --select-optional name=operatorhub
+
+To validate that a bundle's channel names match a regular expression, in addition to required bundle validators
+(the default expression matches "stable", "candidate", "fast", or "x.y" channel names):
+
+  $ operator-sdk bundle validate ./bundle --select-optional name=channel-naming --optional-values=channel-regex='stable|alpha'
+
+Since --optional-values splits its value on commas, a regular expression containing a comma, ex. "[0-9]{1,2}",
+cannot be set with channel-regex. Write it to a file and set channel-regex-file instead:
+
+  $ echo 'stable|v[0-9]{1,2}' > channel-regex.txt
+  $ operator-sdk bundle validate ./bundle --select-optional name=channel-naming --optional-values=channel-regex-file=channel-regex.txt
 `
 )
 
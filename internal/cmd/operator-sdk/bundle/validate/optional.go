@@ -25,6 +25,8 @@ import (
 	apierrors "github.com/operator-framework/api/pkg/validation/errors"
 	interfaces "github.com/operator-framework/api/pkg/validation/interfaces"
 	"k8s.io/apimachinery/pkg/labels"
+
+	internalregistry "github.com/operator-framework/operator-sdk/internal/registry"
 )
 
 // Keys for label selectors to be used by all validators.
@@ -44,11 +46,20 @@ var optionalValidators = validators{
 		},
 		desc: "OperatorHub.io metadata validation",
 	},
+	{
+		Validator: channelNamingValidator,
+		name:      "channel-naming",
+		labels: map[string]string{
+			nameKey: "channel-naming",
+		},
+		desc: "Channel names match a regular expression set by --optional-values=channel-regex=<regex> or channel-regex-file=<path>",
+	},
 }
 
-// runOptionalValidators runs optional validators selected by sel on bundle.
-func runOptionalValidators(bundle *apimanifests.Bundle, sel labels.Selector, optionalValues map[string]string) []apierrors.ManifestResult {
-	return optionalValidators.run(bundle, sel, optionalValues)
+// runOptionalValidators runs optional validators selected by sel on bundle and its metadata.
+func runOptionalValidators(bundle *apimanifests.Bundle, metadata internalregistry.Labels, sel labels.Selector,
+	optionalValues map[string]string) []apierrors.ManifestResult {
+	return optionalValidators.run(bundle, metadata, sel, optionalValues)
 }
 
 // listOptionalValidators lists all optional validators.
@@ -100,8 +111,9 @@ func (vals validators) checkMatches(sel labels.Selector) error {
 	return fmt.Errorf("selector %q does not match any validator labels", sel.String())
 }
 
-// run runs optional validators selected by sel on bundle.
-func (vals validators) run(bundle *apimanifests.Bundle, sel labels.Selector, optionalValues map[string]string) (results []apierrors.ManifestResult) {
+// run runs optional validators selected by sel on bundle and its metadata.
+func (vals validators) run(bundle *apimanifests.Bundle, metadata internalregistry.Labels, sel labels.Selector,
+	optionalValues map[string]string) (results []apierrors.ManifestResult) {
 	// No selector set, do not run any optional validators.
 	if sel == nil || sel.String() == "" {
 		return results
@@ -109,12 +121,15 @@ func (vals validators) run(bundle *apimanifests.Bundle, sel labels.Selector, opt
 
 	// Pass all exposed bundle objects to the validator, since the underlying validator could filter by type
 	// or arbitrary unstructured object keys.
-	// NB(estroz): we may also want to pass metadata to these validators, however the set of metadata in a bundle
-	// object is not complete (only dependencies, no annotations).
+	// The set of metadata in a bundle object is not complete (only dependencies, no annotations),
+	// so metadata is passed separately.
 	objs := bundle.ObjectsToValidate()
 	for _, obj := range bundle.Objects {
 		objs = append(objs, obj)
 	}
+	if metadata != nil {
+		objs = append(objs, metadata)
+	}
 
 	// Pass the --optional-values. e.g. --optional-values="k8s-version=1.22"
 	objs = append(objs, optionalValues)
@@ -126,7 +126,7 @@ func (c bundleValidateCmd) run(logger *log.Entry, bundleRaw string) (res *intern
 	}
 
 	// Read the bundle object and metadata from the created/passed in directory.
-	bundle, mediaType, err := getBundleDataFromDir(c.directory)
+	bundle, metadata, mediaType, err := getBundleDataFromDir(c.directory)
 	if err != nil {
 		return res, err
 	}
@@ -150,7 +150,7 @@ func (c bundleValidateCmd) run(logger *log.Entry, bundleRaw string) (res *intern
 	res.AddManifestResults(results...)
 
 	// Run optional validators.
-	results = runOptionalValidators(bundle, c.selector, c.optionalValues)
+	results = runOptionalValidators(bundle, metadata, c.selector, c.optionalValues)
 	res.AddManifestResults(results...)
 
 	return res, nil
@@ -162,11 +162,11 @@ func (c bundleValidateCmd) list() error {
 }
 
 // getBundleDataFromDir returns the bundle object and associated metadata from dir, if any.
-func getBundleDataFromDir(dir string) (*apimanifests.Bundle, string, error) {
+func getBundleDataFromDir(dir string) (*apimanifests.Bundle, internalregistry.Labels, string, error) {
 	// Gather bundle metadata.
 	metadata, _, err := internalregistry.FindBundleMetadata(dir)
 	if err != nil {
-		return nil, "", err
+		return nil, nil, "", err
 	}
 	manifestsDirName, hasLabel := metadata.GetManifestsDir()
 	if !hasLabel {
@@ -176,14 +176,14 @@ func getBundleDataFromDir(dir string) (*apimanifests.Bundle, string, error) {
 	// Detect mediaType.
 	mediaType, err := registrybundle.GetMediaType(manifestsDir)
 	if err != nil {
-		return nil, "", err
+		return nil, nil, "", err
 	}
 	// Read the bundle.
 	bundle, err := apimanifests.GetBundleFromDir(manifestsDir)
 	if err != nil {
-		return nil, "", err
+		return nil, nil, "", err
 	}
-	return bundle, mediaType, nil
+	return bundle, metadata, mediaType, nil
 }
 
 // newImageRegistryForTool returns an image registry based on what type of image tool is passed.
@@ -23,6 +23,7 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/docker/distribution/reference"
 	"github.com/operator-framework/api/pkg/apis/scorecard/v1alpha3"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -38,6 +39,7 @@ import (
 type scorecardCmd struct {
 	bundle         string
 	config         string
+	gatherDir      string
 	kubeconfig     string
 	namespace      string
 	outputFormat   string
@@ -45,6 +47,8 @@ type scorecardCmd struct {
 	serviceAccount string
 	list           bool
 	skipCleanup    bool
+	storageImage   string
+	untarImage     string
 	waitTime       time.Duration
 }
 
@@ -80,6 +84,13 @@ If the argument holds an image tag, it must be present remotely.`,
 		"Option to enable listing which tests are run")
 	scorecardCmd.Flags().BoolVarP(&c.skipCleanup, "skip-cleanup", "x", false,
 		"Disable resource cleanup after tests are run")
+	scorecardCmd.Flags().StringVar(&c.gatherDir, "gather-dir", "",
+		"Local directory to copy each test's output to before cleanup. Files a test writes to "+
+			scorecard.PodGatherRoot+" and the test's log are copied to a subdirectory named after the test and its pod")
+	scorecardCmd.Flags().StringVar(&c.storageImage, "storage-image", scorecard.DefaultStorageImage,
+		"Storage image used by the sidecar that holds test output until it is gathered with --gather-dir")
+	scorecardCmd.Flags().StringVar(&c.untarImage, "untar-image", scorecard.DefaultUntarImage,
+		"Untar image used by test pods' init container to extract the bundle")
 	scorecardCmd.Flags().DurationVarP(&c.waitTime, "wait-time", "w", 30*time.Second,
 		"seconds to wait for tests to complete. Example: 35s")
 
@@ -142,6 +153,10 @@ func (c *scorecardCmd) run() (err error) {
 	if err != nil {
 		return fmt.Errorf("could not find config file %w", err)
 	}
+	o.Suites, err = scorecard.LoadSuites(configPath)
+	if err != nil {
+		return fmt.Errorf("could not load suites from config file %w", err)
+	}
 
 	o.Selector, err = labels.Parse(c.selector)
 	if err != nil {
@@ -157,12 +172,20 @@ func (c *scorecardCmd) run() (err error) {
 			Namespace:      scorecard.GetKubeNamespace(c.kubeconfig, c.namespace),
 			BundlePath:     c.bundle,
 			BundleMetadata: metadata,
+			UntarImage:     c.untarImage,
+			StorageImage:   c.storageImage,
 		}
 
 		// Only get the client if running tests.
 		if runner.Client, err = scorecard.GetKubeClient(c.kubeconfig); err != nil {
 			return fmt.Errorf("error getting kubernetes client: %w", err)
 		}
+		if c.gatherDir != "" {
+			runner.GatherDir = c.gatherDir
+			if runner.RESTConfig, err = scorecard.GetKubeConfig(c.kubeconfig); err != nil {
+				return fmt.Errorf("error getting kubernetes config: %w", err)
+			}
+		}
 
 		o.TestRunner = &runner
 
@@ -200,6 +223,23 @@ func (c *scorecardCmd) validate(args []string) error {
 	if len(args) != 1 {
 		return fmt.Errorf("a bundle image or directory argument is required")
 	}
+	if err := validateImage("--storage-image", c.storageImage); err != nil {
+		return err
+	}
+	if err := validateImage("--untar-image", c.untarImage); err != nil {
+		return err
+	}
+	return nil
+}
+
+// validateImage returns an error if image, the value of flag, is not an image reference.
+func validateImage(flag, image string) error {
+	if image == "" {
+		return fmt.Errorf("%s must not be empty", flag)
+	}
+	if _, err := reference.ParseNormalizedNamed(image); err != nil {
+		return fmt.Errorf("%s %q is not a valid image reference: %v", flag, image, err)
+	}
 	return nil
 }
 
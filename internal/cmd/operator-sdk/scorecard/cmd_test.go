@@ -17,6 +17,8 @@ package scorecard
 import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+
+	"github.com/operator-framework/operator-sdk/internal/scorecard"
 )
 
 var _ = Describe("Running the scorecard command", func() {
@@ -61,6 +63,18 @@ var _ = Describe("Running the scorecard command", func() {
 			Expect(flag.Shorthand).To(Equal("x"))
 			Expect(flag.DefValue).To(Equal("false"))
 
+			flag = cmd.Flags().Lookup("gather-dir")
+			Expect(flag).NotTo(BeNil())
+			Expect(flag.DefValue).To(Equal(""))
+
+			flag = cmd.Flags().Lookup("storage-image")
+			Expect(flag).NotTo(BeNil())
+			Expect(flag.DefValue).To(Equal(scorecard.DefaultStorageImage))
+
+			flag = cmd.Flags().Lookup("untar-image")
+			Expect(flag).NotTo(BeNil())
+			Expect(flag.DefValue).To(Equal(scorecard.DefaultUntarImage))
+
 			flag = cmd.Flags().Lookup("wait-time")
 			Expect(flag).NotTo(BeNil())
 			Expect(flag.Shorthand).To(Equal("w"))
@@ -71,7 +85,10 @@ var _ = Describe("Running the scorecard command", func() {
 	Describe("validate", func() {
 		var cmd scorecardCmd
 		BeforeEach(func() {
-			cmd = scorecardCmd{}
+			cmd = scorecardCmd{
+				storageImage: scorecard.DefaultStorageImage,
+				untarImage:   scorecard.DefaultUntarImage,
+			}
 		})
 		It("fails if anything other than exactly one arg is provided", func() {
 			err := cmd.validate([]string{})
@@ -86,5 +103,33 @@ var _ = Describe("Running the scorecard command", func() {
 			err := cmd.validate([]string{input})
 			Expect(err).NotTo(HaveOccurred())
 		})
+
+		It("fails if an image is empty", func() {
+			cmd.storageImage = ""
+			Expect(cmd.validate([]string{"cherry"})).To(MatchError(ContainSubstring("--storage-image")))
+
+			cmd.storageImage = scorecard.DefaultStorageImage
+			cmd.untarImage = ""
+			Expect(cmd.validate([]string{"cherry"})).To(MatchError(ContainSubstring("--untar-image")))
+		})
+
+		It("fails if an image contains whitespace", func() {
+			cmd.untarImage = "quay.io/example/busybox: latest"
+			Expect(cmd.validate([]string{"cherry"})).To(MatchError(ContainSubstring("--untar-image")))
+		})
+
+		It("fails if an image is not a valid image reference", func() {
+			for _, image := range []string{"FOO::bar", "quay.io/x:", "quay.io/x@sha256:abc"} {
+				cmd.storageImage = image
+				Expect(cmd.validate([]string{"cherry"})).To(MatchError(ContainSubstring("is not a valid image reference")), image)
+			}
+		})
+
+		It("succeeds for valid image references", func() {
+			for _, image := range []string{"busybox", "quay.io/example/busybox:1.33.0", "localhost:5000/busybox"} {
+				cmd.untarImage = image
+				Expect(cmd.validate([]string{"cherry"})).To(Succeed(), image)
+			}
+		})
 	})
 })
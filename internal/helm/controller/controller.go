@@ -36,6 +36,7 @@ import (
 	libhandler "github.com/operator-framework/operator-lib/handler"
 	"github.com/operator-framework/operator-lib/predicate"
 	"github.com/operator-framework/operator-sdk/internal/helm/release"
+	"github.com/operator-framework/operator-sdk/internal/helm/watches"
 	"github.com/operator-framework/operator-sdk/internal/util/k8sutil"
 )
 
@@ -51,6 +52,8 @@ type WatchOptions struct {
 	WatchDependentResources bool
 	OverrideValues          map[string]string
 	MaxConcurrentReconciles int
+	MaintenanceWindow       *watches.ConfigMapReference
+	ManifestDiff            *ManifestDiffOptions
 }
 
 // Add creates a new helm operator controller and adds it to the manager
@@ -58,12 +61,15 @@ func Add(mgr manager.Manager, options WatchOptions) error {
 	controllerName := fmt.Sprintf("%v-controller", strings.ToLower(options.GVK.Kind))
 
 	r := &HelmOperatorReconciler{
-		Client:          mgr.GetClient(),
-		EventRecorder:   mgr.GetEventRecorderFor(controllerName),
-		GVK:             options.GVK,
-		ManagerFactory:  options.ManagerFactory,
-		ReconcilePeriod: options.ReconcilePeriod,
-		OverrideValues:  options.OverrideValues,
+		Client:            mgr.GetClient(),
+		EventRecorder:     mgr.GetEventRecorderFor(controllerName),
+		GVK:               options.GVK,
+		ManagerFactory:    options.ManagerFactory,
+		ReconcilePeriod:   options.ReconcilePeriod,
+		OverrideValues:    options.OverrideValues,
+		MaintenanceWindow: options.MaintenanceWindow,
+		APIReader:         mgr.GetAPIReader(),
+		ManifestDiff:      options.ManifestDiff,
 	}
 
 	// Register the GVK with the schema
@@ -0,0 +1,69 @@
+// Copyright 2021 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/operator-framework/operator-sdk/internal/helm/internal/maintenance"
+)
+
+// noWindowRequeuePeriod is how often a resource is requeued if no maintenance window starts within
+// the next year and no reconcile period is set, so ConfigMap changes are eventually picked up.
+const noWindowRequeuePeriod = time.Hour
+
+// checkMaintenanceWindow returns true if o should not be reconciled at now because now is outside of
+// all windows in the ConfigMap referenced by r.MaintenanceWindow, along with the time until the next
+// window starts and a message describing when reconciliation will resume.
+func (r HelmOperatorReconciler) checkMaintenanceWindow(ctx context.Context, o *unstructured.Unstructured,
+	now time.Time) (paused bool, requeueAfter time.Duration, message string, err error) {
+
+	key := client.ObjectKey{Name: r.MaintenanceWindow.Name, Namespace: r.MaintenanceWindow.Namespace}
+	if key.Namespace == "" {
+		key.Namespace = o.GetNamespace()
+	}
+
+	// Read the ConfigMap directly, since caching all ConfigMaps in watched namespaces
+	// to read a single one would be wasteful.
+	cm := &corev1.ConfigMap{}
+	if err := r.APIReader.Get(ctx, key, cm); err != nil {
+		return false, 0, "", fmt.Errorf("error getting maintenance window ConfigMap %s: %w", key, err)
+	}
+	schedule, err := maintenance.Parse(cm.Data)
+	if err != nil {
+		return false, 0, "", fmt.Errorf("error parsing maintenance window ConfigMap %s: %w", key, err)
+	}
+
+	if schedule.Active(now) {
+		return false, 0, "", nil
+	}
+
+	next, ok := schedule.Next(now)
+	if !ok {
+		requeueAfter := r.ReconcilePeriod
+		if requeueAfter <= 0 {
+			requeueAfter = noWindowRequeuePeriod
+		}
+		return true, requeueAfter, "Reconciliation is paused, no maintenance window starts within the next year", nil
+	}
+	return true, next.Sub(now), fmt.Sprintf("Reconciliation is paused until the next maintenance window starts at %s",
+		next.Format(time.RFC3339)), nil
+}
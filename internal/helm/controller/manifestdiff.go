@@ -0,0 +1,96 @@
+// Copyright 2021 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/operator-framework/operator-sdk/internal/helm/internal/diff"
+	"github.com/operator-framework/operator-sdk/internal/helm/internal/types"
+)
+
+const (
+	// ManifestDiffSummaryNone records no summary of an upgrade's diff.
+	ManifestDiffSummaryNone = ""
+	// ManifestDiffSummaryEvent records the summary of an upgrade's diff in an Event on the custom resource.
+	ManifestDiffSummaryEvent = "event"
+	// ManifestDiffSummaryCondition records the summary of an upgrade's diff in the UpgradeDiff
+	// condition of the custom resource.
+	ManifestDiffSummaryCondition = "condition"
+
+	// maxManifestDiffSummaryLen keeps summaries within the length of an Event message.
+	maxManifestDiffSummaryLen = 1024
+)
+
+// ManifestDiffOptions configures how the diff between a release's deployed manifest and the manifest
+// it will be upgraded to is reported before an upgrade is applied. Secret data is always redacted.
+type ManifestDiffOptions struct {
+	// LogLevel is the verbosity the diff is logged at. The diff is not logged if LogLevel is negative.
+	LogLevel int
+	// Summary is where a summary of the resources the upgrade changes is recorded, one of
+	// ManifestDiffSummaryNone, ManifestDiffSummaryEvent, or ManifestDiffSummaryCondition.
+	Summary string
+}
+
+// Validate returns an error if o is invalid.
+func (o ManifestDiffOptions) Validate() error {
+	switch o.Summary {
+	case ManifestDiffSummaryNone, ManifestDiffSummaryEvent, ManifestDiffSummaryCondition:
+		return nil
+	}
+	return fmt.Errorf("invalid manifest diff summary %q, must be one of %q or %q",
+		o.Summary, ManifestDiffSummaryEvent, ManifestDiffSummaryCondition)
+}
+
+// reportManifestDiff logs the diff of upgrading o's release from deployedManifest to candidateManifest,
+// and records a summary of it in an Event, as configured by r.ManifestDiff.
+func (r HelmOperatorReconciler) reportManifestDiff(log logr.Logger, o *unstructured.Unstructured,
+	deployedManifest, candidateManifest string) {
+
+	if r.ManifestDiff == nil {
+		return
+	}
+
+	if r.ManifestDiff.LogLevel >= 0 && log.V(r.ManifestDiff.LogLevel).Enabled() {
+		deployed, candidate := diff.RedactSecrets(deployedManifest, candidateManifest)
+		log.V(r.ManifestDiff.LogLevel).Info("Upgrading release", "diff", diff.GeneratePlain(deployed, candidate))
+	}
+
+	if r.ManifestDiff.Summary == ManifestDiffSummaryEvent {
+		summary := diff.Summarize(deployedManifest, candidateManifest, maxManifestDiffSummaryLen)
+		r.EventRecorder.Event(o, "Normal", "UpgradeDiff", summary)
+	}
+}
+
+// setUpgradeDiffCondition sets the UpgradeDiff condition in status to a summary of the upgrade from
+// deployedManifest to candidateManifest, with reason describing the upgrade's outcome, if r.ManifestDiff
+// records summaries in a condition. The condition describes the most recent upgrade, so reconciles that
+// do not upgrade the release leave it as-is.
+func (r HelmOperatorReconciler) setUpgradeDiffCondition(status *types.HelmAppStatus,
+	deployedManifest, candidateManifest string, reason types.HelmAppConditionReason) {
+
+	if r.ManifestDiff == nil || r.ManifestDiff.Summary != ManifestDiffSummaryCondition {
+		return
+	}
+	status.SetCondition(types.HelmAppCondition{
+		Type:    types.ConditionUpgradeDiff,
+		Status:  types.StatusTrue,
+		Reason:  reason,
+		Message: diff.Summarize(deployedManifest, candidateManifest, maxManifestDiffSummaryLen),
+	})
+}
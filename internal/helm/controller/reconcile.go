@@ -36,6 +36,7 @@ import (
 	"github.com/operator-framework/operator-sdk/internal/helm/internal/diff"
 	"github.com/operator-framework/operator-sdk/internal/helm/internal/types"
 	"github.com/operator-framework/operator-sdk/internal/helm/release"
+	"github.com/operator-framework/operator-sdk/internal/helm/watches"
 )
 
 // blank assignment to verify that HelmOperatorReconciler implements reconcile.Reconciler
@@ -52,7 +53,14 @@ type HelmOperatorReconciler struct {
 	ManagerFactory  release.ManagerFactory
 	ReconcilePeriod time.Duration
 	OverrideValues  map[string]string
-	releaseHook     ReleaseHookFunc
+	// MaintenanceWindow, if set, references a ConfigMap defining when releases may be
+	// installed or upgraded. Outside of those windows only uninstalls are reconciled.
+	MaintenanceWindow *watches.ConfigMapReference
+	// APIReader reads the MaintenanceWindow ConfigMap.
+	APIReader client.Reader
+	// ManifestDiff, if set, reports the diff of each upgrade before it is applied.
+	ManifestDiff *ManifestDiffOptions
+	releaseHook  ReleaseHookFunc
 }
 
 const (
@@ -63,6 +71,8 @@ const (
 
 	helmUpgradeForceAnnotation  = "helm.sdk.operatorframework.io/upgrade-force"
 	helmUninstallWaitAnnotation = "helm.sdk.operatorframework.io/uninstall-wait"
+	// helmReconcileEmergencyAnnotation allows a resource to be reconciled outside of a maintenance window.
+	helmReconcileEmergencyAnnotation = "helm.sdk.operatorframework.io/reconcile-emergency"
 )
 
 // Reconcile reconciles the requested resource by installing, updating, or
@@ -131,7 +141,7 @@ func (r HelmOperatorReconciler) Reconcile(ctx context.Context, request reconcile
 		} else {
 			log.Info("Uninstalled release")
 			if log.V(0).Enabled() && uninstalledRelease != nil {
-				fmt.Println(diff.Generate(uninstalledRelease.Manifest, ""))
+				fmt.Println(diff.Generate(diff.RedactSecrets(uninstalledRelease.Manifest, "")))
 			}
 			if !wait {
 				status.SetCondition(types.HelmAppCondition{
@@ -201,6 +211,36 @@ func (r HelmOperatorReconciler) Reconcile(ctx context.Context, request reconcile
 		Status: types.StatusTrue,
 	})
 
+	if r.MaintenanceWindow != nil && !hasAnnotation(helmReconcileEmergencyAnnotation, o) {
+		paused, requeueAfter, message, err := r.checkMaintenanceWindow(ctx, o, time.Now())
+		if err != nil {
+			// Fail closed, since reconciling outside of a maintenance window is worse than not reconciling.
+			log.Error(err, "Failed to check maintenance window")
+			status.SetCondition(types.HelmAppCondition{
+				Type:    types.ConditionReconcilePaused,
+				Status:  types.StatusTrue,
+				Reason:  types.ReasonMaintenanceWindowError,
+				Message: err.Error(),
+			})
+			if err := r.updateResourceStatus(ctx, o, status); err != nil {
+				log.Error(err, "Failed to update status after maintenance window failure")
+			}
+			return reconcile.Result{}, err
+		}
+		if paused {
+			log.Info("Outside of maintenance window, pausing reconciliation", "requeueAfter", requeueAfter.String())
+			status.SetCondition(types.HelmAppCondition{
+				Type:    types.ConditionReconcilePaused,
+				Status:  types.StatusTrue,
+				Reason:  types.ReasonOutsideMaintenanceWindow,
+				Message: message,
+			})
+			err := r.updateResourceStatus(ctx, o, status)
+			return reconcile.Result{RequeueAfter: requeueAfter}, err
+		}
+	}
+	status.RemoveCondition(types.ConditionReconcilePaused)
+
 	if err := manager.Sync(ctx); err != nil {
 		log.Error(err, "Failed to sync release")
 		status.SetCondition(types.HelmAppCondition{
@@ -253,7 +293,7 @@ func (r HelmOperatorReconciler) Reconcile(ctx context.Context, request reconcile
 
 		log.Info("Installed release")
 		if log.V(0).Enabled() {
-			fmt.Println(diff.Generate("", installedRelease.Manifest))
+			fmt.Println(diff.Generate(diff.RedactSecrets("", installedRelease.Manifest)))
 		}
 		log.V(1).Info("Config values", "values", installedRelease.Config)
 		message := ""
@@ -290,6 +330,9 @@ func (r HelmOperatorReconciler) Reconcile(ctx context.Context, request reconcile
 			r.EventRecorder.Eventf(o, "Warning", "OverrideValuesInUse",
 				"Chart value %q overridden to %q by operator's watches.yaml", k, v)
 		}
+		deployedManifest, candidateManifest := manager.PendingUpgrade()
+		r.reportManifestDiff(log, o, deployedManifest, candidateManifest)
+
 		force := hasAnnotation(helmUpgradeForceAnnotation, o)
 		previousRelease, upgradedRelease, err := manager.UpgradeRelease(ctx, release.ForceUpgrade(force))
 		if err != nil {
@@ -300,12 +343,14 @@ func (r HelmOperatorReconciler) Reconcile(ctx context.Context, request reconcile
 				Reason:  types.ReasonUpgradeError,
 				Message: err.Error(),
 			})
+			r.setUpgradeDiffCondition(status, deployedManifest, candidateManifest, types.ReasonUpgradeError)
 			if err := r.updateResourceStatus(ctx, o, status); err != nil {
 				log.Error(err, "Failed to update status after sync release failure")
 			}
 			return reconcile.Result{}, err
 		}
 		status.RemoveCondition(types.ConditionReleaseFailed)
+		r.setUpgradeDiffCondition(status, deployedManifest, candidateManifest, types.ReasonUpgradeSuccessful)
 
 		if r.releaseHook != nil {
 			if err := r.releaseHook(upgradedRelease); err != nil {
@@ -316,7 +361,7 @@ func (r HelmOperatorReconciler) Reconcile(ctx context.Context, request reconcile
 
 		log.Info("Upgraded release", "force", force)
 		if log.V(0).Enabled() {
-			fmt.Println(diff.Generate(previousRelease.Manifest, upgradedRelease.Manifest))
+			fmt.Println(diff.Generate(diff.RedactSecrets(previousRelease.Manifest, upgradedRelease.Manifest)))
 		}
 		log.V(1).Info("Config values", "values", upgradedRelease.Config)
 		message := ""
@@ -15,10 +15,20 @@
 package controller
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/record"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/operator-framework/operator-sdk/internal/helm/internal/maintenance"
+	"github.com/operator-framework/operator-sdk/internal/helm/internal/types"
+	"github.com/operator-framework/operator-sdk/internal/helm/watches"
 )
 
 func TestHasAnnotation(t *testing.T) {
@@ -131,6 +141,94 @@ func TestHasAnnotation(t *testing.T) {
 	}
 }
 
+func TestCheckMaintenanceWindow(t *testing.T) {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "maintenance-windows", Namespace: "change-control"},
+		// 2021-05-01 is a Saturday.
+		Data: map[string]string{maintenance.WindowsKey: "0 2 * * 6 4h"},
+	}
+	o := &unstructured.Unstructured{}
+	o.SetNamespace("helm-test")
+	windowStart := time.Date(2021, time.May, 1, 2, 0, 0, 0, time.UTC)
+
+	r := HelmOperatorReconciler{
+		MaintenanceWindow: &watches.ConfigMapReference{Name: cm.Name, Namespace: cm.Namespace},
+		APIReader:         fakeclient.NewClientBuilder().WithObjects(cm).Build(),
+	}
+
+	paused, _, _, err := r.checkMaintenanceWindow(context.TODO(), o, windowStart.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.False(t, paused, "inside window")
+
+	paused, requeueAfter, message, err := r.checkMaintenanceWindow(context.TODO(), o, windowStart.Add(-time.Hour))
+	assert.NoError(t, err)
+	assert.True(t, paused, "outside window")
+	assert.Equal(t, time.Hour, requeueAfter)
+	assert.Contains(t, message, windowStart.Format(time.RFC3339))
+
+	// The ConfigMap is looked up in the resource's namespace if the reference has none.
+	r.MaintenanceWindow = &watches.ConfigMapReference{Name: cm.Name}
+	_, _, _, err = r.checkMaintenanceWindow(context.TODO(), o, windowStart)
+	assert.Error(t, err, "ConfigMap not in resource namespace")
+	o.SetNamespace(cm.Namespace)
+	_, _, _, err = r.checkMaintenanceWindow(context.TODO(), o, windowStart)
+	assert.NoError(t, err)
+
+	// Resources are still requeued if no window starts within the next year and there is no reconcile period.
+	cm.Data[maintenance.WindowsKey] = "0 0 29 2 * 1h"
+	r.APIReader = fakeclient.NewClientBuilder().WithObjects(cm).Build()
+	paused, requeueAfter, _, err = r.checkMaintenanceWindow(context.TODO(), o, windowStart)
+	assert.NoError(t, err)
+	assert.True(t, paused, "no window within a year")
+	assert.Equal(t, noWindowRequeuePeriod, requeueAfter)
+}
+
+func TestReportManifestDiff(t *testing.T) {
+	deployed := "---\napiVersion: v1\nkind: Secret\nmetadata:\n  name: app\ndata:\n  password: b2xk\n"
+	candidate := "---\napiVersion: v1\nkind: Secret\nmetadata:\n  name: app\ndata:\n  password: bmV3\n"
+	expectedSummary := "0 added, 1 changed, 0 removed: changed Secret/app"
+	o := &unstructured.Unstructured{}
+
+	recorder := record.NewFakeRecorder(1)
+	status := &types.HelmAppStatus{}
+	r := HelmOperatorReconciler{EventRecorder: recorder}
+	r.reportManifestDiff(log, o, deployed, candidate)
+	r.setUpgradeDiffCondition(status, deployed, candidate, types.ReasonUpgradeSuccessful)
+	assert.Empty(t, recorder.Events, "manifest diff not enabled")
+	assert.Empty(t, status.Conditions, "manifest diff not enabled")
+
+	r.ManifestDiff = &ManifestDiffOptions{LogLevel: -1, Summary: ManifestDiffSummaryEvent}
+	r.reportManifestDiff(log, o, deployed, candidate)
+	r.setUpgradeDiffCondition(status, deployed, candidate, types.ReasonUpgradeSuccessful)
+	assert.Equal(t, "Normal UpgradeDiff "+expectedSummary, <-recorder.Events)
+	assert.Empty(t, status.Conditions, "summary recorded in an event")
+
+	r.ManifestDiff = &ManifestDiffOptions{LogLevel: -1, Summary: ManifestDiffSummaryCondition}
+	r.reportManifestDiff(log, o, deployed, candidate)
+	r.setUpgradeDiffCondition(status, deployed, candidate, types.ReasonUpgradeError)
+	assert.Empty(t, recorder.Events, "summary recorded in a condition")
+	if assert.Len(t, status.Conditions, 1) {
+		assert.Equal(t, types.ConditionUpgradeDiff, status.Conditions[0].Type)
+		assert.Equal(t, types.ReasonUpgradeError, status.Conditions[0].Reason)
+		assert.Equal(t, expectedSummary, status.Conditions[0].Message)
+	}
+
+	// A later upgrade replaces the condition left by a previous upgrade with its own summary and outcome.
+	added := candidate + "---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: app\n"
+	r.setUpgradeDiffCondition(status, candidate, added, types.ReasonUpgradeSuccessful)
+	if assert.Len(t, status.Conditions, 1) {
+		assert.Equal(t, types.ReasonUpgradeSuccessful, status.Conditions[0].Reason)
+		assert.Equal(t, "1 added, 0 changed, 0 removed: added ConfigMap/app", status.Conditions[0].Message)
+	}
+}
+
+func TestManifestDiffOptionsValidate(t *testing.T) {
+	for _, summary := range []string{ManifestDiffSummaryNone, ManifestDiffSummaryEvent, ManifestDiffSummaryCondition} {
+		assert.NoError(t, ManifestDiffOptions{Summary: summary}.Validate(), summary)
+	}
+	assert.Error(t, ManifestDiffOptions{Summary: "log"}.Validate())
+}
+
 func annotations(m map[string]interface{}) *unstructured.Unstructured {
 	return &unstructured.Unstructured{
 		Object: map[string]interface{}{
@@ -33,6 +33,8 @@ type Flags struct {
 	LeaderElectionNamespace string
 	MaxConcurrentReconciles int
 	ProbeAddr               string
+	ManifestDiffLogLevel    int
+	ManifestDiffSummary     string
 
 	// Path to a controller-runtime componentconfig file.
 	// If this is empty, use default values.
@@ -65,6 +67,19 @@ func (f *Flags) AddTo(flagSet *pflag.FlagSet) {
 		runtime.NumCPU(),
 		"Maximum number of concurrent reconciles for controllers.",
 	)
+	flagSet.IntVar(&f.ManifestDiffLogLevel,
+		"manifest-diff-log-level",
+		-1,
+		"Log level at which the diff between a release's deployed manifest and its upgraded manifest is"+
+			" logged before each upgrade, with Secret data redacted. A negative level disables logging the diff.",
+	)
+	flagSet.StringVar(&f.ManifestDiffSummary,
+		"manifest-diff-summary",
+		"",
+		"Record a summary of the resources each upgrade adds, changes, and removes before the upgrade is"+
+			" applied, in an Event (\"event\") or the UpgradeDiff status condition (\"condition\") of the"+
+			" custom resource. No summary is recorded if empty.",
+	)
 
 	// Controller manager flags.
 	flagSet.StringVar(&f.ManagerConfigPath,
@@ -0,0 +1,128 @@
+// Copyright 2021 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const deployedManifest = `---
+# Source: app/templates/configmap.yaml
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: app-config
+data:
+  replicas: "1"
+---
+# Source: app/templates/secret.yaml
+apiVersion: v1
+kind: Secret
+metadata:
+  name: app-secret
+data:
+  password: b2xkLXBhc3N3b3Jk
+  username: YWRtaW4=
+---
+# Source: app/templates/service.yaml
+apiVersion: v1
+kind: Service
+metadata:
+  name: app
+spec:
+  ports:
+  - port: 80
+`
+
+const candidateManifest = `---
+# Source: app/templates/configmap.yaml
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: app-config
+data:
+  replicas: "2"
+---
+# Source: app/templates/secret.yaml
+apiVersion: v1
+kind: Secret
+metadata:
+  name: app-secret
+data:
+  password: bmV3LXBhc3N3b3Jk
+  username: YWRtaW4=
+stringData:
+  token: new-token
+---
+# Source: app/templates/deployment.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  namespace: apps
+spec:
+  replicas: 2
+`
+
+func TestGeneratePlain(t *testing.T) {
+	assert.Equal(t, " a\n-b\n+c\n", GeneratePlain("a\nb\n", "a\nc\n"))
+	assert.NotContains(t, GeneratePlain("a\nb\n", "a\nc\n"), "\x1b[")
+}
+
+func TestRedactSecrets(t *testing.T) {
+	deployed, candidate := RedactSecrets(deployedManifest, candidateManifest)
+
+	for _, secret := range []string{"b2xkLXBhc3N3b3Jk", "bmV3LXBhc3N3b3Jk", "YWRtaW4=", "new-token"} {
+		assert.NotContains(t, deployed, secret)
+		assert.NotContains(t, candidate, secret)
+	}
+	assert.Contains(t, deployed, "password: <redacted, previous value>")
+	assert.Contains(t, candidate, "password: <redacted, changed value>")
+	assert.Contains(t, deployed, "username: <redacted>")
+	assert.Contains(t, candidate, "username: <redacted>")
+	assert.Contains(t, candidate, "token: <redacted>")
+
+	// Documents other than Secrets, and the comments Helm adds, are unchanged.
+	assert.Contains(t, deployed, "---\n# Source: app/templates/secret.yaml\napiVersion: v1\n")
+	assert.Contains(t, deployed, "data:\n  replicas: \"1\"\n")
+	assert.True(t, strings.HasSuffix(candidate, "spec:\n  replicas: 2\n"))
+
+	// The diff shows which Secret keys changed.
+	d := GeneratePlain(deployed, candidate)
+	assert.Contains(t, d, "-  password: <redacted, previous value>\n")
+	assert.Contains(t, d, "+  password: <redacted, changed value>\n")
+	assert.Contains(t, d, "   username: <redacted>\n")
+}
+
+func TestRedactSecretsWithoutSecrets(t *testing.T) {
+	manifest := "---\n# Source: app/templates/service.yaml\napiVersion: v1\nkind: Service\nmetadata:\n  name: app\n"
+	deployed, candidate := RedactSecrets(manifest, "")
+	assert.Equal(t, manifest, deployed)
+	assert.Equal(t, "", candidate)
+}
+
+func TestSummarize(t *testing.T) {
+	assert.Equal(t, "1 added, 2 changed, 1 removed: added Deployment/app (namespace apps), "+
+		"changed ConfigMap/app-config, changed Secret/app-secret, removed Service/app",
+		Summarize(deployedManifest, candidateManifest, 1024))
+	assert.Equal(t, "0 added, 0 changed, 0 removed", Summarize(deployedManifest, deployedManifest, 1024))
+
+	summary := Summarize(deployedManifest, candidateManifest, 40)
+	assert.Len(t, summary, 40)
+	assert.Equal(t, "1 added, 2 changed, 1 removed: added ...", summary)
+}
@@ -0,0 +1,159 @@
+// Copyright 2021 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	redacted         = "<redacted>"
+	redactedPrevious = "<redacted, previous value>"
+	redactedChanged  = "<redacted, changed value>"
+)
+
+// secretFields are the fields of a Secret whose values are redacted.
+var secretFields = []string{"data", "stringData"}
+
+// documentSep matches the separators between the documents of a release manifest.
+var documentSep = regexp.MustCompile(`(?m)^---[ \t]*\n`)
+
+// document is a single resource of a release manifest.
+type document struct {
+	text string
+	obj  map[string]interface{}
+}
+
+// splitManifest splits manifest into its documents, in order. A document that cannot be parsed
+// has a nil obj.
+func splitManifest(manifest string) []document {
+	var docs []document
+	for _, text := range documentSep.Split(manifest, -1) {
+		doc := document{text: text}
+		if err := yaml.Unmarshal([]byte(text), &doc.obj); err != nil {
+			doc.obj = nil
+		}
+		docs = append(docs, doc)
+	}
+	return docs
+}
+
+// joinManifest reverses splitManifest.
+func joinManifest(docs []document) string {
+	texts := make([]string, len(docs))
+	for i, doc := range docs {
+		texts[i] = doc.text
+	}
+	return strings.Join(texts, "---\n")
+}
+
+// resourceID identifies the resource doc describes, or is empty if doc does not describe a resource.
+type resourceID struct {
+	kind, namespace, name string
+}
+
+func (d document) id() resourceID {
+	kind, _ := d.obj["kind"].(string)
+	metadata, _ := d.obj["metadata"].(map[string]interface{})
+	name, _ := metadata["name"].(string)
+	namespace, _ := metadata["namespace"].(string)
+	return resourceID{kind: kind, namespace: namespace, name: name}
+}
+
+func (id resourceID) String() string {
+	if id.namespace == "" {
+		return fmt.Sprintf("%s/%s", id.kind, id.name)
+	}
+	return fmt.Sprintf("%s/%s (namespace %s)", id.kind, id.name, id.namespace)
+}
+
+// RedactSecrets returns release manifests a and b with the value of every key of every Secret's data
+// replaced. A key whose value differs between the same Secret in a and b is replaced with a different
+// marker in each, so a diff of the results shows which keys changed without revealing their values.
+func RedactSecrets(a, b string) (string, string) {
+	docsA, docsB := splitManifest(a), splitManifest(b)
+	secretsA, secretsB := secretData(docsA), secretData(docsB)
+	return redactSecrets(docsA, secretsB, redactedPrevious), redactSecrets(docsB, secretsA, redactedChanged)
+}
+
+// secretData returns a copy of the data of each Secret in docs, keyed by Secret and field.
+func secretData(docs []document) map[resourceID]map[string]map[string]interface{} {
+	secrets := map[resourceID]map[string]map[string]interface{}{}
+	for _, doc := range docs {
+		id := doc.id()
+		if id.kind != "Secret" {
+			continue
+		}
+		secrets[id] = map[string]map[string]interface{}{}
+		for _, field := range secretFields {
+			values, _ := doc.obj[field].(map[string]interface{})
+			secrets[id][field] = make(map[string]interface{}, len(values))
+			for key, value := range values {
+				secrets[id][field][key] = value
+			}
+		}
+	}
+	return secrets
+}
+
+// redactSecrets redacts the Secrets in docs, replacing values that differ from the same key of the same
+// Secret in others with changedMarker.
+func redactSecrets(docs []document, others map[resourceID]map[string]map[string]interface{},
+	changedMarker string) string {
+
+	for i, doc := range docs {
+		id := doc.id()
+		if id.kind != "Secret" {
+			continue
+		}
+		for _, field := range secretFields {
+			values, _ := doc.obj[field].(map[string]interface{})
+			for key, value := range values {
+				otherValue, found := others[id][field][key]
+				if found && !reflect.DeepEqual(otherValue, value) {
+					values[key] = changedMarker
+				} else {
+					values[key] = redacted
+				}
+			}
+		}
+		b, err := yaml.Marshal(doc.obj)
+		if err != nil {
+			// doc was parsed from YAML, so this should never happen. Drop the document rather
+			// than risk revealing its data.
+			b = []byte(fmt.Sprintf("# %s could not be redacted\n", id))
+		}
+		docs[i].text = leadingComments(doc.text) + string(b)
+	}
+	return joinManifest(docs)
+}
+
+// leadingComments returns the comment and blank lines at the start of text, such as the
+// "# Source:" comment Helm adds to each document of a release manifest.
+func leadingComments(text string) string {
+	var b strings.Builder
+	for _, line := range strings.SplitAfter(text, "\n") {
+		if trimmed := strings.TrimSpace(line); trimmed != "" && !strings.HasPrefix(trimmed, "#") {
+			break
+		}
+		b.WriteString(line)
+	}
+	return b.String()
+}
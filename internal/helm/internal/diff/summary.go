@@ -0,0 +1,90 @@
+// Copyright 2021 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const truncatedSuffix = "..."
+
+// Summarize returns a summary of the resources added, changed, and removed by changing release
+// manifest a to b, truncated to at most maxLen bytes. The summary names resources but does not
+// contain any of their fields, so it is safe to record even if the manifests contain Secrets.
+func Summarize(a, b string, maxLen int) string {
+	resourcesA, resourcesB := resourcesByID(splitManifest(a)), resourcesByID(splitManifest(b))
+
+	var added, changed, removed []string
+	for id, objB := range resourcesB {
+		objA, found := resourcesA[id]
+		switch {
+		case !found:
+			added = append(added, id.String())
+		case !reflect.DeepEqual(objA, objB):
+			changed = append(changed, id.String())
+		}
+	}
+	for id := range resourcesA {
+		if _, found := resourcesB[id]; !found {
+			removed = append(removed, id.String())
+		}
+	}
+
+	summary := fmt.Sprintf("%d added, %d changed, %d removed", len(added), len(changed), len(removed))
+	var details []string
+	for _, resources := range []struct {
+		verb string
+		ids  []string
+	}{
+		{"added", added},
+		{"changed", changed},
+		{"removed", removed},
+	} {
+		sort.Strings(resources.ids)
+		for _, id := range resources.ids {
+			details = append(details, fmt.Sprintf("%s %s", resources.verb, id))
+		}
+	}
+	if len(details) > 0 {
+		summary += ": " + strings.Join(details, ", ")
+	}
+	return truncate(summary, maxLen)
+}
+
+// resourcesByID returns the parsed resources in docs. Documents that do not describe a resource,
+// such as those only containing comments, are ignored.
+func resourcesByID(docs []document) map[resourceID]map[string]interface{} {
+	resources := map[resourceID]map[string]interface{}{}
+	for _, doc := range docs {
+		if id := doc.id(); id.kind != "" && id.name != "" {
+			resources[id] = doc.obj
+		}
+	}
+	return resources
+}
+
+// truncate shortens s to at most maxLen bytes, ending it with truncatedSuffix if it was shortened.
+func truncate(s string, maxLen int) string {
+	if len(s) <= maxLen {
+		return s
+	}
+	if maxLen <= len(truncatedSuffix) {
+		return s[:maxLen]
+	}
+	return s[:maxLen-len(truncatedSuffix)] + truncatedSuffix
+}
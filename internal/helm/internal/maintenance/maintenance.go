@@ -0,0 +1,247 @@
+// Copyright 2021 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package maintenance parses maintenance windows, during which the Helm
+// operator is allowed to reconcile releases, from ConfigMap data.
+package maintenance
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// WindowsKey is the ConfigMap data key containing maintenance windows, one per line.
+	// Each window is a 5-field cron schedule for the window's start followed by its duration,
+	// ex. "0 2 * * 6 4h" for a 4 hour window starting at 02:00 every Saturday.
+	// Empty lines and lines starting with "#" are ignored.
+	WindowsKey = "windows"
+	// TimeZoneKey is the optional ConfigMap data key containing the IANA time zone
+	// windows are evaluated in, ex. "America/New_York". Defaults to UTC.
+	TimeZoneKey = "timezone"
+
+	// maxLookahead bounds the search for the next window start.
+	maxLookahead = 366 * 24 * time.Hour
+)
+
+// Schedule is a set of maintenance windows.
+type Schedule struct {
+	windows  []window
+	location *time.Location
+}
+
+type window struct {
+	start    cronSchedule
+	duration time.Duration
+}
+
+// Parse parses a Schedule from ConfigMap data.
+func Parse(data map[string]string) (*Schedule, error) {
+	s := &Schedule{location: time.UTC}
+	if tz, ok := data[TimeZoneKey]; ok && strings.TrimSpace(tz) != "" {
+		loc, err := time.LoadLocation(strings.TrimSpace(tz))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", TimeZoneKey, err)
+		}
+		s.location = loc
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(data[WindowsKey]))
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		w, err := parseWindow(line)
+		if err != nil {
+			return nil, fmt.Errorf("invalid window on line %d of %s: %w", lineNum, WindowsKey, err)
+		}
+		s.windows = append(s.windows, w)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(s.windows) == 0 {
+		return nil, fmt.Errorf("no windows found in %s", WindowsKey)
+	}
+	return s, nil
+}
+
+func parseWindow(line string) (w window, err error) {
+	fields := strings.Fields(line)
+	if len(fields) != 6 {
+		return w, fmt.Errorf("expected 5 cron fields and a duration, got %q", line)
+	}
+	if w.start, err = parseCron(fields[:5]); err != nil {
+		return w, err
+	}
+	if w.duration, err = time.ParseDuration(fields[5]); err != nil {
+		return w, err
+	}
+	if w.duration < time.Minute || w.duration > maxLookahead {
+		return w, fmt.Errorf("duration %s must be between 1m and %s", w.duration, maxLookahead)
+	}
+	return w, nil
+}
+
+// Active returns true if t is within any window.
+func (s *Schedule) Active(t time.Time) bool {
+	t = t.In(s.location).Truncate(time.Minute)
+	for _, w := range s.windows {
+		// A window is active if it started within its duration before t.
+		if _, ok := w.start.next(t.Add(-w.duration).Add(time.Minute), t.Add(time.Minute)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Next returns the start of the first window after t, or false if no window
+// starts within the next year.
+func (s *Schedule) Next(t time.Time) (time.Time, bool) {
+	t = t.In(s.location).Truncate(time.Minute)
+	end := t.Add(maxLookahead)
+	var first time.Time
+	found := false
+	for _, w := range s.windows {
+		if next, ok := w.start.next(t.Add(time.Minute), end); ok && (!found || next.Before(first)) {
+			first, found = next, true
+		}
+	}
+	return first, found
+}
+
+// cronSchedule is a parsed 5-field cron expression: minute, hour, day of month, month, day of week.
+type cronSchedule struct {
+	minute, hour, dom, month, dow fieldSet
+	// domStar and dowStar record whether day of month and day of week were "*",
+	// since cron matches either field, not both, when both are restricted.
+	domStar, dowStar bool
+}
+
+type fieldSet map[int]struct{}
+
+// next returns the first minute at or after t and before end that c matches, or false if there is none.
+// Rather than checking every minute, it skips to the start of the next month, day, or hour whenever
+// that field does not match, so a year is searched in at most a few hundred steps.
+func (c cronSchedule) next(t, end time.Time) (time.Time, bool) {
+	loc := t.Location()
+	for t = t.Truncate(time.Minute); t.Before(end); {
+		switch {
+		case !c.month.has(int(t.Month())):
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !c.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case !c.hour.has(t.Hour()):
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		case !c.minute.has(t.Minute()):
+			t = t.Add(time.Minute)
+		default:
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func (c cronSchedule) dayMatches(t time.Time) bool {
+	domMatch, dowMatch := c.dom.has(t.Day()), c.dow.has(int(t.Weekday()))
+	if c.domStar || c.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+func (f fieldSet) has(i int) bool {
+	_, ok := f[i]
+	return ok
+}
+
+func parseCron(fields []string) (c cronSchedule, err error) {
+	if c.minute, err = parseField(fields[0], 0, 59); err != nil {
+		return c, fmt.Errorf("minute: %w", err)
+	}
+	if c.hour, err = parseField(fields[1], 0, 23); err != nil {
+		return c, fmt.Errorf("hour: %w", err)
+	}
+	if c.dom, err = parseField(fields[2], 1, 31); err != nil {
+		return c, fmt.Errorf("day of month: %w", err)
+	}
+	if c.month, err = parseField(fields[3], 1, 12); err != nil {
+		return c, fmt.Errorf("month: %w", err)
+	}
+	// Day of week 7 is an alias for Sunday (0).
+	if c.dow, err = parseField(fields[4], 0, 7); err != nil {
+		return c, fmt.Errorf("day of week: %w", err)
+	}
+	if c.dow.has(7) {
+		delete(c.dow, 7)
+		c.dow[0] = struct{}{}
+	}
+	c.domStar, c.dowStar = fields[2] == "*", fields[4] == "*"
+	return c, nil
+}
+
+// parseField parses a comma-separated list of values, ranges "a-b", and "*",
+// each optionally followed by a step "/n", within [min, max].
+func parseField(field string, min, max int) (fieldSet, error) {
+	set := fieldSet{}
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			if step, err = strconv.Atoi(part[i+1:]); err != nil || step < 1 {
+				return nil, fmt.Errorf("invalid step in %q", part)
+			}
+			part = part[:i]
+		}
+
+		lo, hi := min, max
+		switch {
+		case part == "*":
+		case strings.Contains(part, "-"):
+			bounds := strings.SplitN(part, "-", 2)
+			var err error
+			if lo, err = strconv.Atoi(bounds[0]); err != nil {
+				return nil, fmt.Errorf("invalid range %q", part)
+			}
+			if hi, err = strconv.Atoi(bounds[1]); err != nil {
+				return nil, fmt.Errorf("invalid range %q", part)
+			}
+		default:
+			v, err := strconv.Atoi(part)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value %q", part)
+			}
+			lo, hi = v, v
+			// "n/step" means every step starting at n.
+			if step > 1 {
+				hi = max
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return nil, fmt.Errorf("%q is out of range [%d, %d]", part, min, max)
+		}
+		for v := lo; v <= hi; v += step {
+			set[v] = struct{}{}
+		}
+	}
+	if len(set) == 0 {
+		return nil, errors.New("no values")
+	}
+	return set, nil
+}
@@ -0,0 +1,156 @@
+// Copyright 2021 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintenance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		name      string
+		data      map[string]string
+		expectErr bool
+	}{
+		{
+			name: "valid",
+			data: map[string]string{WindowsKey: "# weekend window\n0 2 * * 6,0 4h\n\n*/15 9-17 1 * * 5m\n"},
+		},
+		{
+			name: "valid with time zone",
+			data: map[string]string{WindowsKey: "0 2 * * 6 4h", TimeZoneKey: "UTC"},
+		},
+		{
+			name:      "no windows",
+			data:      map[string]string{WindowsKey: "# nothing here\n"},
+			expectErr: true,
+		},
+		{
+			name:      "missing duration",
+			data:      map[string]string{WindowsKey: "0 2 * * 6"},
+			expectErr: true,
+		},
+		{
+			name:      "invalid duration",
+			data:      map[string]string{WindowsKey: "0 2 * * 6 4x"},
+			expectErr: true,
+		},
+		{
+			name:      "duration too short",
+			data:      map[string]string{WindowsKey: "0 2 * * 6 30s"},
+			expectErr: true,
+		},
+		{
+			name:      "out of range",
+			data:      map[string]string{WindowsKey: "0 24 * * 6 1h"},
+			expectErr: true,
+		},
+		{
+			name:      "invalid range",
+			data:      map[string]string{WindowsKey: "0 5-2 * * 6 1h"},
+			expectErr: true,
+		},
+		{
+			name:      "invalid step",
+			data:      map[string]string{WindowsKey: "*/0 2 * * 6 1h"},
+			expectErr: true,
+		},
+		{
+			name:      "invalid time zone",
+			data:      map[string]string{WindowsKey: "0 2 * * 6 4h", TimeZoneKey: "Not/AZone"},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Parse(tc.data)
+			if tc.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestActiveAndNext(t *testing.T) {
+	// 2021-05-01 is a Saturday.
+	s, err := Parse(map[string]string{WindowsKey: "0 2 * * 6 4h"})
+	assert.NoError(t, err)
+
+	windowStart := time.Date(2021, time.May, 1, 2, 0, 0, 0, time.UTC)
+	assert.True(t, s.Active(windowStart))
+	assert.True(t, s.Active(windowStart.Add(3*time.Hour+59*time.Minute)))
+	assert.False(t, s.Active(windowStart.Add(4*time.Hour)))
+	assert.False(t, s.Active(windowStart.Add(-time.Minute)))
+
+	next, ok := s.Next(windowStart.Add(4 * time.Hour))
+	assert.True(t, ok)
+	assert.Equal(t, windowStart.AddDate(0, 0, 7), next)
+}
+
+func TestActiveAndNextLongWindows(t *testing.T) {
+	// A yearly window, and a window lasting most of a year.
+	s, err := Parse(map[string]string{WindowsKey: "0 0 1 1 * 1h\n0 0 1 3 * 8000h"})
+	assert.NoError(t, err)
+
+	newYear := time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)
+	next, ok := s.Next(time.Date(2021, time.February, 1, 0, 0, 0, 0, time.UTC))
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC), next)
+	assert.True(t, s.Active(newYear.Add(-time.Minute)), "inside long window")
+	assert.True(t, s.Active(newYear.Add(59*time.Minute)), "inside yearly window")
+	assert.False(t, s.Active(time.Date(2022, time.February, 1, 0, 0, 0, 0, time.UTC)))
+
+	// February 29th does not occur within a year of 2021-03-01.
+	s, err = Parse(map[string]string{WindowsKey: "0 0 29 2 * 1h"})
+	assert.NoError(t, err)
+	_, ok = s.Next(time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC))
+	assert.False(t, ok)
+	next, ok = s.Next(time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC))
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC), next)
+}
+
+func TestActiveWithTimeZone(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("Time zone data not available: %v", err)
+	}
+	s, err := Parse(map[string]string{WindowsKey: "0 2 * * * 1h", TimeZoneKey: "America/New_York"})
+	assert.NoError(t, err)
+
+	assert.True(t, s.Active(time.Date(2021, time.May, 1, 2, 30, 0, 0, loc)))
+	assert.False(t, s.Active(time.Date(2021, time.May, 1, 2, 30, 0, 0, time.UTC)))
+}
+
+func TestCronDayMatching(t *testing.T) {
+	// Day of month and day of week are ORed when both are restricted.
+	s, err := Parse(map[string]string{WindowsKey: "0 0 1 * 1 1h"})
+	assert.NoError(t, err)
+	// 2021-05-01 is a Saturday, 2021-05-03 is a Monday.
+	assert.True(t, s.Active(time.Date(2021, time.May, 1, 0, 0, 0, 0, time.UTC)))
+	assert.True(t, s.Active(time.Date(2021, time.May, 3, 0, 0, 0, 0, time.UTC)))
+	assert.False(t, s.Active(time.Date(2021, time.May, 4, 0, 0, 0, 0, time.UTC)))
+
+	// Day of week 7 is Sunday. 2021-05-02 is a Sunday.
+	s, err = Parse(map[string]string{WindowsKey: "0 0 * * 7 1h"})
+	assert.NoError(t, err)
+	assert.True(t, s.Active(time.Date(2021, time.May, 2, 0, 30, 0, 0, time.UTC)))
+}
@@ -56,22 +56,26 @@ type HelmAppRelease struct {
 }
 
 const (
-	ConditionInitialized    HelmAppConditionType = "Initialized"
-	ConditionDeployed       HelmAppConditionType = "Deployed"
-	ConditionReleaseFailed  HelmAppConditionType = "ReleaseFailed"
-	ConditionIrreconcilable HelmAppConditionType = "Irreconcilable"
+	ConditionInitialized     HelmAppConditionType = "Initialized"
+	ConditionDeployed        HelmAppConditionType = "Deployed"
+	ConditionReleaseFailed   HelmAppConditionType = "ReleaseFailed"
+	ConditionIrreconcilable  HelmAppConditionType = "Irreconcilable"
+	ConditionReconcilePaused HelmAppConditionType = "ReconcilePaused"
+	ConditionUpgradeDiff     HelmAppConditionType = "UpgradeDiff"
 
 	StatusTrue    ConditionStatus = "True"
 	StatusFalse   ConditionStatus = "False"
 	StatusUnknown ConditionStatus = "Unknown"
 
-	ReasonInstallSuccessful   HelmAppConditionReason = "InstallSuccessful"
-	ReasonUpgradeSuccessful   HelmAppConditionReason = "UpgradeSuccessful"
-	ReasonUninstallSuccessful HelmAppConditionReason = "UninstallSuccessful"
-	ReasonInstallError        HelmAppConditionReason = "InstallError"
-	ReasonUpgradeError        HelmAppConditionReason = "UpgradeError"
-	ReasonReconcileError      HelmAppConditionReason = "ReconcileError"
-	ReasonUninstallError      HelmAppConditionReason = "UninstallError"
+	ReasonInstallSuccessful        HelmAppConditionReason = "InstallSuccessful"
+	ReasonUpgradeSuccessful        HelmAppConditionReason = "UpgradeSuccessful"
+	ReasonUninstallSuccessful      HelmAppConditionReason = "UninstallSuccessful"
+	ReasonInstallError             HelmAppConditionReason = "InstallError"
+	ReasonUpgradeError             HelmAppConditionReason = "UpgradeError"
+	ReasonReconcileError           HelmAppConditionReason = "ReconcileError"
+	ReasonUninstallError           HelmAppConditionReason = "UninstallError"
+	ReasonOutsideMaintenanceWindow HelmAppConditionReason = "OutsideMaintenanceWindow"
+	ReasonMaintenanceWindowError   HelmAppConditionReason = "MaintenanceWindowError"
 )
 
 type HelmAppStatus struct {
@@ -51,6 +51,7 @@ type Manager interface {
 	ReleaseName() string
 	IsInstalled() bool
 	IsUpgradeRequired() bool
+	PendingUpgrade() (deployedManifest, candidateManifest string)
 	Sync(context.Context) error
 	InstallRelease(context.Context, ...InstallOption) (*rpb.Release, error)
 	UpgradeRelease(context.Context, ...UpgradeOption) (*rpb.Release, *rpb.Release, error)
@@ -73,6 +74,7 @@ type manager struct {
 	isInstalled       bool
 	isUpgradeRequired bool
 	deployedRelease   *rpb.Release
+	candidateRelease  *rpb.Release
 	chart             *cpb.Chart
 }
 
@@ -93,6 +95,15 @@ func (m manager) IsUpgradeRequired() bool {
 	return m.isUpgradeRequired
 }
 
+// PendingUpgrade returns the manifests of the deployed release and of the release it will
+// be upgraded to, as computed by Sync. Both are empty if no upgrade is required.
+func (m manager) PendingUpgrade() (deployedManifest, candidateManifest string) {
+	if !m.isUpgradeRequired {
+		return "", ""
+	}
+	return m.deployedRelease.Manifest, m.candidateRelease.Manifest
+}
+
 // Sync ensures the Helm storage backend is in sync with the status of the
 // custom resource.
 func (m *manager) Sync(ctx context.Context) error {
@@ -130,6 +141,7 @@ func (m *manager) Sync(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("failed to get candidate release: %w", err)
 	}
+	m.candidateRelease = candidateRelease
 	if deployedRelease.Manifest != candidateRelease.Manifest {
 		m.isUpgradeRequired = true
 	}
@@ -35,6 +35,18 @@ type Watch struct {
 	ChartDir                string            `json:"chart"`
 	WatchDependentResources *bool             `json:"watchDependentResources,omitempty"`
 	OverrideValues          map[string]string `json:"overrideValues,omitempty"`
+	// MaintenanceWindow references a ConfigMap defining the windows during which
+	// releases may be installed or upgraded. If unset, releases are always reconciled.
+	MaintenanceWindow *ConfigMapReference `json:"maintenanceWindow,omitempty"`
+}
+
+// ConfigMapReference references a ConfigMap by name and namespace.
+type ConfigMapReference struct {
+	// Name is the ConfigMap's name.
+	Name string `json:"configMapName"`
+	// Namespace is the ConfigMap's namespace. If empty, the namespace of the
+	// custom resource being reconciled is used.
+	Namespace string `json:"configMapNamespace,omitempty"`
 }
 
 // UnmarshalYAML unmarshals an individual watch from the Helm watches.yaml file
@@ -102,6 +114,10 @@ func LoadReader(reader io.Reader) ([]Watch, error) {
 			return nil, fmt.Errorf("invalid chart directory %s: %w", w.ChartDir, err)
 		}
 
+		if w.MaintenanceWindow != nil && w.MaintenanceWindow.Name == "" {
+			return nil, fmt.Errorf("invalid maintenance window for GVK %s: configMapName must not be empty", gvk)
+		}
+
 		if _, ok := watchesMap[gvk]; ok {
 			return nil, fmt.Errorf("duplicate GVK: %s", gvk)
 		}
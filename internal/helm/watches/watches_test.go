@@ -76,6 +76,42 @@ func TestLoadReader(t *testing.T) {
 			},
 			expectErr: false,
 		},
+		{
+			name: "valid with maintenance window",
+			data: `---
+- group: mygroup
+  version: v1alpha1
+  kind: MyKind
+  chart: ../../../internal/plugins/helm/v1/chartutil/testdata/test-chart
+  maintenanceWindow:
+    configMapName: maintenance-windows
+    configMapNamespace: change-control
+`,
+			expectWatches: []Watch{
+				{
+					GroupVersionKind:        schema.GroupVersionKind{Group: "mygroup", Version: "v1alpha1", Kind: "MyKind"},
+					ChartDir:                "../../../internal/plugins/helm/v1/chartutil/testdata/test-chart",
+					WatchDependentResources: &trueVal,
+					MaintenanceWindow: &ConfigMapReference{
+						Name:      "maintenance-windows",
+						Namespace: "change-control",
+					},
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "maintenance window without configmap name",
+			data: `---
+- group: mygroup
+  version: v1alpha1
+  kind: MyKind
+  chart: ../../../internal/plugins/helm/v1/chartutil/testdata/test-chart
+  maintenanceWindow:
+    configMapNamespace: change-control
+`,
+			expectErr: true,
+		},
 		{
 			name: "multiple gvk",
 			data: `---
@@ -15,6 +15,7 @@
 package scorecard
 
 import (
+	"fmt"
 	"io/ioutil"
 
 	"github.com/operator-framework/api/pkg/apis/scorecard/v1alpha3"
@@ -45,3 +46,50 @@ func LoadConfig(configFilePath string) (v1alpha3.Configuration, error) {
 	err = yaml.Unmarshal(yamlFile, &c)
 	return c, err
 }
+
+// SuiteConfiguration is a group of tests that share expensive setup, ex. seed data, and teardown.
+// Suites are not part of the v1alpha3 Configuration, and are loaded from the "suites" key
+// of the same config file by LoadSuites.
+type SuiteConfiguration struct {
+	// Name identifies the suite in test results.
+	Name string `json:"name"`
+	// Parallel runs the suite's tests in parallel, like a parallel stage.
+	Parallel bool `json:"parallel,omitempty"`
+	// Setup, if set, runs once before the suite's tests. If setup does not pass,
+	// the suite's tests are skipped.
+	Setup *v1alpha3.TestConfiguration `json:"setup,omitempty"`
+	// Teardown, if set, runs once after the suite's tests, even if setup or the tests fail.
+	Teardown *v1alpha3.TestConfiguration `json:"teardown,omitempty"`
+	// Tests are the suite's tests, which run in the same namespace as setup and teardown.
+	Tests []v1alpha3.TestConfiguration `json:"tests"`
+}
+
+// LoadSuites returns the suites defined in the scorecard config file at configFilePath, if any.
+func LoadSuites(configFilePath string) ([]SuiteConfiguration, error) {
+	c := struct {
+		Suites []SuiteConfiguration `json:"suites,omitempty"`
+	}{}
+
+	yamlFile, err := ioutil.ReadFile(configFilePath)
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(yamlFile, &c); err != nil {
+		return nil, err
+	}
+
+	names := map[string]struct{}{}
+	for i, suite := range c.Suites {
+		if suite.Name == "" {
+			return nil, fmt.Errorf("suite %d: name must not be empty", i)
+		}
+		if _, ok := names[suite.Name]; ok {
+			return nil, fmt.Errorf("duplicate suite name %q", suite.Name)
+		}
+		names[suite.Name] = struct{}{}
+		if len(suite.Tests) == 0 {
+			return nil, fmt.Errorf("suite %q: at least one test must be defined", suite.Name)
+		}
+	}
+	return c.Suites, nil
+}
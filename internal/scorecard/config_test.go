@@ -15,6 +15,10 @@
 package scorecard
 
 import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -43,3 +47,68 @@ func TestInvalidConfigPath(t *testing.T) {
 
 	}
 }
+
+func TestLoadSuites(t *testing.T) {
+	cases := []struct {
+		name         string
+		config       string
+		expectSuites int
+		wantError    bool
+	}{
+		{"no suites", "stages: []\n", 0, false},
+		{"valid suite", `
+suites:
+- name: seeded
+  setup:
+    image: quay.io/example/seed:v0.0.1
+  tests:
+  - image: quay.io/example/test:v0.0.1
+`, 1, false},
+		{"suite without name", `
+suites:
+- tests:
+  - image: quay.io/example/test:v0.0.1
+`, 0, true},
+		{"duplicate suite names", `
+suites:
+- name: seeded
+  tests:
+  - image: quay.io/example/test:v0.0.1
+- name: seeded
+  tests:
+  - image: quay.io/example/test:v0.0.1
+`, 0, true},
+		{"suite without tests", `
+suites:
+- name: seeded
+`, 0, true},
+	}
+
+	dir, err := ioutil.TempDir("", "scorecard-config-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for i, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			configPath := filepath.Join(dir, fmt.Sprintf("config-%d.yaml", i))
+			if err := ioutil.WriteFile(configPath, []byte(c.config), 0644); err != nil {
+				t.Fatal(err)
+			}
+			suites, err := LoadSuites(configPath)
+			if c.wantError {
+				if err == nil {
+					t.Fatalf("Wanted error but got no error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Wanted result but got error: %v", err)
+			}
+			if len(suites) != c.expectSuites {
+				t.Errorf("Wanted %d suites, got %d", c.expectSuites, len(suites))
+			}
+		})
+	}
+}
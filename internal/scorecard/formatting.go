@@ -49,5 +49,22 @@ func (o Scorecard) List() v1alpha3.TestList {
 			output.Items = append(output.Items, item)
 		}
 	}
+	for _, suite := range o.Suites {
+		tests := o.selectFrom(suite.Tests)
+		if len(tests) == 0 {
+			continue
+		}
+		if suite.Setup != nil {
+			tests = append([]v1alpha3.TestConfiguration{*suite.Setup}, tests...)
+		}
+		if suite.Teardown != nil {
+			tests = append(tests, *suite.Teardown)
+		}
+		for _, test := range tests {
+			item := v1alpha3.NewTest()
+			item.Spec = test
+			output.Items = append(output.Items, item)
+		}
+	}
 	return output
 }
@@ -0,0 +1,113 @@
+// Copyright 2021 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scorecard
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/operator-framework/api/pkg/apis/scorecard/v1alpha3"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// gatherTestOutput copies the contents of PodGatherRoot from pod's gather sidecar
+// into a subdirectory of r.GatherDir named after test and pod.
+func (r PodTestRunner) gatherTestOutput(ctx context.Context, pod *v1.Pod, test v1alpha3.TestConfiguration) error {
+	if r.RESTConfig == nil {
+		return fmt.Errorf("no REST config set to gather test output from pod %s", pod.Name)
+	}
+
+	req := r.Client.CoreV1().RESTClient().Post().
+		Namespace(pod.Namespace).
+		Resource("pods").
+		Name(pod.Name).
+		SubResource("exec").
+		VersionedParams(&v1.PodExecOptions{
+			Container: gatherContainerName,
+			Command:   []string{"tar", "cf", "-", "-C", PodGatherRoot, "."},
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+
+	exec, err := remotecommand.NewSPDYExecutor(r.RESTConfig, "POST", req.URL())
+	if err != nil {
+		return fmt.Errorf("error creating executor for pod %s: %w", pod.Name, err)
+	}
+
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	streamErr := make(chan error, 1)
+	go func() {
+		streamErr <- exec.Stream(remotecommand.StreamOptions{Stdout: stdout, Stderr: stderr})
+	}()
+	select {
+	case err = <-streamErr:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	if err != nil {
+		return fmt.Errorf("error copying test output from pod %s: %v: %s", pod.Name, err, stderr.String())
+	}
+
+	dir, err := gatherTestDir(r.GatherDir, pod, test)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	if err := Untar(stdout, dir); err != nil {
+		return fmt.Errorf("error extracting test output from pod %s: %w", pod.Name, err)
+	}
+
+	// The test container's log is an artifact in its own right, so store it alongside the output.
+	logBytes, err := getPodLog(ctx, r.Client, pod)
+	if err != nil {
+		return fmt.Errorf("error getting log of pod %s: %w", pod.Name, err)
+	}
+	return ioutil.WriteFile(filepath.Join(dir, "scorecard-test.log"), logBytes, 0644)
+}
+
+// gatherTestDir returns the directory in gatherDir that test's output is gathered to.
+func gatherTestDir(gatherDir string, pod *v1.Pod, test v1alpha3.TestConfiguration) (string, error) {
+	root, err := filepath.Abs(gatherDir)
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(root, gatherDirName(pod, test))
+	// Test labels come from the scorecard config, which may be read from a remote bundle image,
+	// so make sure no name can write outside of gatherDir.
+	if rel, err := filepath.Rel(root, dir); err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return "", fmt.Errorf("gather directory of pod %s is not in %s", pod.Name, gatherDir)
+	}
+	return dir, nil
+}
+
+// gatherDirName returns the name of the directory test's output is gathered to, which is the test's
+// "test" label, if set and a valid directory name, followed by the name of the pod it ran in.
+// The pod name is always included since several tests may have the same label.
+func gatherDirName(pod *v1.Pod, test v1alpha3.TestConfiguration) string {
+	name := test.Labels["test"]
+	if name == "" || name == "." || strings.Contains(name, "..") || strings.ContainsAny(name, `/\`) {
+		return pod.Name
+	}
+	return name + "-" + pod.Name
+}
@@ -19,6 +19,7 @@ import (
 
 	"github.com/operator-framework/operator-sdk/internal/util/k8sutil"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	cruntime "sigs.k8s.io/controller-runtime/pkg/client/config"
 )
@@ -32,11 +33,7 @@ import (
 // TODO(joelanford): migrate scorecard use `internal/operator.Configuration`
 func GetKubeClient(kubeconfig string) (client kubernetes.Interface, err error) {
 
-	if kubeconfig != "" {
-		os.Setenv(k8sutil.KubeConfigEnvVar, kubeconfig)
-	}
-
-	config, err := cruntime.GetConfig()
+	config, err := GetKubeConfig(kubeconfig)
 	if err != nil {
 		return client, err
 	}
@@ -50,6 +47,15 @@ func GetKubeClient(kubeconfig string) (client kubernetes.Interface, err error) {
 	return clientset, err
 }
 
+// GetKubeConfig returns the REST config that GetKubeClient creates its client from,
+// for callers that need to talk to the API server directly, ex. to exec into a pod.
+func GetKubeConfig(kubeconfig string) (*rest.Config, error) {
+	if kubeconfig != "" {
+		os.Setenv(k8sutil.KubeConfigEnvVar, kubeconfig)
+	}
+	return cruntime.GetConfig()
+}
+
 // GetKubeNamespace returns the kubernetes namespace to use
 // for scorecard pod creation
 // the order of how the namespace is determined is as follows:
@@ -21,11 +21,13 @@ import (
 	"time"
 
 	"github.com/operator-framework/api/pkg/apis/scorecard/v1alpha3"
+	log "github.com/sirupsen/logrus"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 
 	registryutil "github.com/operator-framework/operator-sdk/internal/registry"
 )
@@ -37,7 +39,9 @@ type TestRunner interface {
 }
 
 type Scorecard struct {
-	Config      v1alpha3.Configuration
+	Config v1alpha3.Configuration
+	// Suites run after all stages in Config.
+	Suites      []SuiteConfiguration
 	Selector    labels.Selector
 	TestRunner  TestRunner
 	SkipCleanup bool
@@ -49,6 +53,14 @@ type PodTestRunner struct {
 	BundlePath     string
 	BundleMetadata registryutil.Labels
 	Client         kubernetes.Interface
+	// RESTConfig is used to exec into test pods, and must be set if GatherDir is set.
+	RESTConfig *rest.Config
+	// GatherDir, if set, is the local directory each test's output is copied to before cleanup.
+	GatherDir string
+	// UntarImage is the image used to untar the bundle in test pods. Defaults to DefaultUntarImage.
+	UntarImage string
+	// StorageImage is the image used to hold test output until it is gathered. Defaults to DefaultStorageImage.
+	StorageImage string
 
 	configMapName string
 }
@@ -59,6 +71,9 @@ type FakeTestRunner struct {
 	Error      error
 }
 
+// SkipState is the state of a suite's tests that were not run because the suite's setup did not pass.
+const SkipState v1alpha3.State = "skip"
+
 // cleanupTimeout is the time given to clean up resources, regardless of how long ctx's deadline is.
 var cleanupTimeout = time.Second * 30
 
@@ -88,6 +103,10 @@ func (o Scorecard) Run(ctx context.Context) (testOutput v1alpha3.TestList, err e
 		}
 	}
 
+	for _, suite := range o.Suites {
+		testOutput.Items = append(testOutput.Items, o.runSuite(ctx, suite)...)
+	}
+
 	// Get timeout error, if any, before calling Cleanup() so deletes don't cause a timeout.
 	select {
 	case <-ctx.Done():
@@ -125,6 +144,52 @@ func (o Scorecard) runStageSequential(ctx context.Context, tests []v1alpha3.Test
 	}
 }
 
+// runSuite runs suite's setup, then its selected tests, then its teardown. If no tests are selected,
+// nothing is run. If setup does not pass, the tests are skipped, but teardown is still run.
+func (o Scorecard) runSuite(ctx context.Context, suite SuiteConfiguration) (results []v1alpha3.Test) {
+	tests := o.selectFrom(suite.Tests)
+	if len(tests) == 0 {
+		return nil
+	}
+
+	setupPassed := true
+	if suite.Setup != nil {
+		setup := o.runTest(ctx, *suite.Setup)
+		results = append(results, setup)
+		setupPassed = hasPassed(setup)
+	}
+
+	if setupPassed {
+		output := make(chan v1alpha3.Test, len(tests))
+		if suite.Parallel {
+			o.runStageParallel(ctx, tests, output)
+		} else {
+			o.runStageSequential(ctx, tests, output)
+		}
+		close(output)
+		for t := range output {
+			results = append(results, t)
+		}
+	} else {
+		for _, test := range tests {
+			results = append(results, skipTest(test, fmt.Sprintf("skipped: setup of suite %q did not pass", suite.Name)))
+		}
+	}
+
+	if suite.Teardown != nil {
+		// Teardown must run even if the suite's tests exceeded ctx's deadline.
+		tdctx := ctx
+		if ctx.Err() != nil {
+			var cancel context.CancelFunc
+			tdctx, cancel = context.WithTimeout(context.Background(), cleanupTimeout)
+			defer cancel()
+		}
+		results = append(results, o.runTest(tdctx, *suite.Teardown))
+	}
+
+	return results
+}
+
 func (o Scorecard) runTest(ctx context.Context, test v1alpha3.TestConfiguration) v1alpha3.Test {
 	result, err := o.TestRunner.RunTest(ctx, test)
 	if err != nil {
@@ -140,8 +205,14 @@ func (o Scorecard) runTest(ctx context.Context, test v1alpha3.TestConfiguration)
 // selectTests applies an optionally passed selector expression
 // against the configured set of tests, returning the selected tests
 func (o *Scorecard) selectTests(stage v1alpha3.StageConfiguration) []v1alpha3.TestConfiguration {
+	return o.selectFrom(stage.Tests)
+}
+
+// selectFrom applies an optionally passed selector expression
+// against tests, returning the selected tests
+func (o *Scorecard) selectFrom(tests []v1alpha3.TestConfiguration) []v1alpha3.TestConfiguration {
 	selected := make([]v1alpha3.TestConfiguration, 0)
-	for _, test := range stage.Tests {
+	for _, test := range tests {
 		if o.Selector == nil || o.Selector.String() == "" || o.Selector.Matches(labels.Set(test.Labels)) {
 			// TODO olm manifests check
 			selected = append(selected, test)
@@ -210,6 +281,12 @@ func (r PodTestRunner) RunTest(ctx context.Context, test v1alpha3.TestConfigurat
 		return nil, err
 	}
 
+	if r.GatherDir != "" {
+		if err := r.gatherTestOutput(ctx, pod, test); err != nil {
+			log.Errorf("Failed to gather output of test pod %s: %v", pod.Name, err)
+		}
+	}
+
 	return r.getTestStatus(ctx, pod), nil
 }
 
@@ -240,6 +317,12 @@ func (r PodTestRunner) waitForTestToComplete(ctx context.Context, p *v1.Pod) (er
 		if tmp.Status.Phase == v1.PodSucceeded || tmp.Status.Phase == v1.PodFailed {
 			return true, nil
 		}
+		// The pod keeps running after the test completes if it has a gather sidecar.
+		for _, cs := range tmp.Status.ContainerStatuses {
+			if cs.Name == testContainerName && cs.State.Terminated != nil {
+				return true, nil
+			}
+		}
 		return false, nil
 	})
 
@@ -248,6 +331,33 @@ func (r PodTestRunner) waitForTestToComplete(ctx context.Context, p *v1.Pod) (er
 
 }
 
+// hasPassed returns true if test has results and all of them passed.
+func hasPassed(test v1alpha3.Test) bool {
+	if len(test.Status.Results) == 0 {
+		return false
+	}
+	for _, r := range test.Status.Results {
+		if r.State != v1alpha3.PassState {
+			return false
+		}
+	}
+	return true
+}
+
+// skipTest returns the output of a test that was not run for reason.
+func skipTest(test v1alpha3.TestConfiguration, reason string) v1alpha3.Test {
+	out := v1alpha3.NewTest()
+	out.Spec = test
+	out.Status = v1alpha3.TestStatus{
+		Results: []v1alpha3.TestResult{{
+			Name:  test.Labels["test"],
+			State: SkipState,
+			Log:   reason,
+		}},
+	}
+	return out
+}
+
 func convertErrorToStatus(err error, log string) *v1alpha3.TestStatus {
 	result := v1alpha3.TestResult{}
 	result.State = v1alpha3.FailState
@@ -0,0 +1,103 @@
+// Copyright 2021 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scorecard
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/operator-framework/api/pkg/apis/scorecard/v1alpha3"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// suiteTestRunner returns a result with the state mapped to by a test's image,
+// and records the images of all run tests.
+type suiteTestRunner struct {
+	FakeTestRunner
+	states map[string]v1alpha3.State
+	ran    *[]string
+}
+
+func (r suiteTestRunner) RunTest(ctx context.Context, test v1alpha3.TestConfiguration) (*v1alpha3.TestStatus, error) {
+	*r.ran = append(*r.ran, test.Image)
+	state, ok := r.states[test.Image]
+	if !ok {
+		state = v1alpha3.PassState
+	}
+	return &v1alpha3.TestStatus{Results: []v1alpha3.TestResult{{State: state}}}, nil
+}
+
+var _ = Describe("Running suites", func() {
+	var (
+		suite  SuiteConfiguration
+		states map[string]v1alpha3.State
+		ran    []string
+	)
+
+	BeforeEach(func() {
+		suite = SuiteConfiguration{
+			Name:     "seeded",
+			Setup:    &v1alpha3.TestConfiguration{Image: "setup"},
+			Teardown: &v1alpha3.TestConfiguration{Image: "teardown"},
+			Tests: []v1alpha3.TestConfiguration{
+				{Image: "test1", Labels: map[string]string{"test": "test1"}},
+				{Image: "test2", Labels: map[string]string{"test": "test2"}},
+			},
+		}
+		states = nil
+		ran = []string{}
+	})
+
+	// run runs suite with the tests selected by selector, returning the state of each result.
+	run := func(selector string) []v1alpha3.State {
+		sel, err := labels.Parse(selector)
+		ExpectWithOffset(1, err).NotTo(HaveOccurred())
+		o := Scorecard{
+			Suites:      []SuiteConfiguration{suite},
+			Selector:    sel,
+			TestRunner:  suiteTestRunner{states: states, ran: &ran},
+			SkipCleanup: true,
+		}
+		tests, err := o.Run(context.Background())
+		ExpectWithOffset(1, err).NotTo(HaveOccurred())
+		var results []v1alpha3.State
+		for _, test := range tests.Items {
+			results = append(results, test.Status.Results[0].State)
+		}
+		return results
+	}
+
+	It("runs setup, tests, and teardown in order", func() {
+		Expect(run("")).To(Equal([]v1alpha3.State{
+			v1alpha3.PassState, v1alpha3.PassState, v1alpha3.PassState, v1alpha3.PassState,
+		}))
+		Expect(ran).To(Equal([]string{"setup", "test1", "test2", "teardown"}))
+	})
+	It("skips tests but runs teardown if setup fails", func() {
+		states = map[string]v1alpha3.State{"setup": v1alpha3.FailState}
+		Expect(run("")).To(Equal([]v1alpha3.State{v1alpha3.FailState, SkipState, SkipState, v1alpha3.PassState}))
+		Expect(ran).To(Equal([]string{"setup", "teardown"}))
+	})
+	It("runs only selected tests", func() {
+		Expect(run("test=test2")).To(Equal([]v1alpha3.State{v1alpha3.PassState, v1alpha3.PassState, v1alpha3.PassState}))
+		Expect(ran).To(Equal([]string{"setup", "test2", "teardown"}))
+	})
+	It("runs nothing if no tests are selected", func() {
+		Expect(run("test=test3")).To(BeEmpty())
+		Expect(ran).To(BeEmpty())
+	})
+})
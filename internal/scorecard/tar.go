@@ -102,12 +102,7 @@ func UntarFile(tarName, target string) (err error) {
 		}
 	}()
 
-	absPath, err := filepath.Abs(target)
-	if err != nil {
-		return err
-	}
-
-	var tr *tar.Reader
+	var r io.Reader = tarFile
 	if isFileGzipped(tarName) {
 		gz, err := gzip.NewReader(tarFile)
 		if err != nil {
@@ -118,11 +113,21 @@ func UntarFile(tarName, target string) (err error) {
 				log.Error(err)
 			}
 		}()
-		tr = tar.NewReader(gz)
-	} else {
-		tr = tar.NewReader(tarFile)
+		r = gz
+	}
+
+	return Untar(r, target)
+}
+
+// Untar reads an uncompressed tar stream from r into a location.
+func Untar(r io.Reader, target string) (err error) {
+	absPath, err := filepath.Abs(target)
+	if err != nil {
+		return err
 	}
 
+	tr := tar.NewReader(r)
+
 	// untar each segment
 	for {
 		hdr, err := tr.Next()
@@ -143,6 +148,9 @@ func UntarFile(tarName, target string) (err error) {
 			}
 		}
 		absFileName := filepath.Join(absPath, fileName)
+		if absFileName != absPath && !strings.HasPrefix(absFileName, absPath+string(filepath.Separator)) {
+			return fmt.Errorf("tar entry %q resolves outside of %s", hdr.Name, absPath)
+		}
 
 		if finfo.Mode().IsDir() {
 			if err := os.MkdirAll(absFileName, 0755); err != nil {
@@ -31,15 +31,32 @@ const (
 	// PodBundleRoot is the directory containing all bundle data within a test pod.
 	PodBundleRoot = "/bundle"
 
-	// The image used to untar bundles prior to running tests within a runner Pod.
+	// DefaultUntarImage is the default image used to untar bundles prior to running tests within a runner Pod.
 	// This image tag should always be pinned to a specific version.
-	scorecardUntarImage = "docker.io/busybox:1.33.0"
+	DefaultUntarImage = "docker.io/busybox:1.33.0"
+
+	// PodGatherRoot is the directory within a test pod whose contents are gathered
+	// to the local machine after the test completes, if gathering is enabled.
+	PodGatherRoot = "/test-output"
+
+	// DefaultStorageImage is the default image used by the sidecar container that holds a test's output
+	// until it is gathered. This image tag should always be pinned to a specific version.
+	DefaultStorageImage = "docker.io/busybox:1.33.0"
+
+	testContainerName   = "scorecard-test"
+	gatherContainerName = "scorecard-gather"
+	gatherVolumeName    = "scorecard-gather"
 )
 
 // getPodDefinition fills out a Pod definition based on
 // information from the test
 func getPodDefinition(configMapName string, test v1alpha3.TestConfiguration, r PodTestRunner) *v1.Pod {
-	return &v1.Pod{
+	untarImage := r.UntarImage
+	if untarImage == "" {
+		untarImage = DefaultUntarImage
+	}
+
+	pod := &v1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      fmt.Sprintf("scorecard-test-%s", rand.String(4)),
 			Namespace: r.Namespace,
@@ -53,7 +70,7 @@ func getPodDefinition(configMapName string, test v1alpha3.TestConfiguration, r P
 			RestartPolicy:      v1.RestartPolicyNever,
 			Containers: []v1.Container{
 				{
-					Name:            "scorecard-test",
+					Name:            testContainerName,
 					Image:           test.Image,
 					ImagePullPolicy: v1.PullIfNotPresent,
 					Command:         test.Entrypoint,
@@ -79,7 +96,7 @@ func getPodDefinition(configMapName string, test v1alpha3.TestConfiguration, r P
 			InitContainers: []v1.Container{
 				{
 					Name:            "scorecard-untar",
-					Image:           scorecardUntarImage,
+					Image:           untarImage,
 					ImagePullPolicy: v1.PullIfNotPresent,
 					Args: []string{
 						"tar",
@@ -122,11 +139,46 @@ func getPodDefinition(configMapName string, test v1alpha3.TestConfiguration, r P
 			},
 		},
 	}
+
+	if r.GatherDir != "" {
+		storageImage := r.StorageImage
+		if storageImage == "" {
+			storageImage = DefaultStorageImage
+		}
+		addGatherSidecar(pod, storageImage)
+	}
+
+	return pod
+}
+
+// addGatherSidecar mounts a volume at PodGatherRoot in the test container and adds a sidecar
+// container that shares that volume, so the test's output can be copied out of the sidecar
+// after the test container has exited. The sidecar runs image.
+func addGatherSidecar(pod *v1.Pod, image string) {
+	pod.Spec.Volumes = append(pod.Spec.Volumes, v1.Volume{
+		Name: gatherVolumeName,
+		VolumeSource: v1.VolumeSource{
+			EmptyDir: &v1.EmptyDirVolumeSource{},
+		},
+	})
+	mount := v1.VolumeMount{
+		MountPath: PodGatherRoot,
+		Name:      gatherVolumeName,
+	}
+	pod.Spec.Containers[0].VolumeMounts = append(pod.Spec.Containers[0].VolumeMounts, mount)
+	pod.Spec.Containers = append(pod.Spec.Containers, v1.Container{
+		Name:            gatherContainerName,
+		Image:           image,
+		ImagePullPolicy: v1.PullIfNotPresent,
+		// The sidecar only needs to outlive the test container; the pod is deleted on cleanup.
+		Command:      []string{"/bin/sh", "-c", "sleep 3600"},
+		VolumeMounts: []v1.VolumeMount{mount},
+	})
 }
 
 // getPodLog fetches the test results which are found in the pod log
 func getPodLog(ctx context.Context, client kubernetes.Interface, pod *v1.Pod) ([]byte, error) {
-	req := client.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &v1.PodLogOptions{})
+	req := client.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &v1.PodLogOptions{Container: testContainerName})
 	podLogs, err := req.Stream(ctx)
 	if err != nil {
 		return nil, err
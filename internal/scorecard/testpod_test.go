@@ -0,0 +1,133 @@
+// Copyright 2021 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scorecard
+
+import (
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/operator-framework/api/pkg/apis/scorecard/v1alpha3"
+	v1 "k8s.io/api/core/v1"
+)
+
+var _ = Describe("Test pods", func() {
+	Describe("getPodDefinition", func() {
+		var (
+			r    PodTestRunner
+			test v1alpha3.TestConfiguration
+		)
+
+		BeforeEach(func() {
+			r = PodTestRunner{Namespace: "test-ns", ServiceAccount: "test-sa"}
+			test = v1alpha3.TestConfiguration{
+				Image:      "quay.io/example/test:v0.0.1",
+				Entrypoint: []string{"test"},
+			}
+		})
+
+		It("creates a single test container when not gathering output", func() {
+			pod := getPodDefinition("cm", test, r)
+			Expect(pod.Spec.Containers).To(HaveLen(1))
+			Expect(pod.Spec.Containers[0].Name).To(Equal(testContainerName))
+			Expect(pod.Spec.Containers[0].Image).To(Equal(test.Image))
+			Expect(pod.Spec.Volumes).To(HaveLen(2))
+			Expect(pod.Spec.InitContainers[0].Image).To(Equal(DefaultUntarImage))
+		})
+
+		It("uses the untar image set on the runner", func() {
+			r.UntarImage = "registry.example.com/busybox:1.33.0"
+			pod := getPodDefinition("cm", test, r)
+			Expect(pod.Spec.InitContainers[0].Image).To(Equal(r.UntarImage))
+		})
+
+		It("adds a gather sidecar sharing the output volume when gathering output", func() {
+			r.GatherDir = "gathered"
+			pod := getPodDefinition("cm", test, r)
+			Expect(pod.Spec.Containers).To(HaveLen(2))
+			Expect(pod.Spec.Containers[1].Name).To(Equal(gatherContainerName))
+			Expect(pod.Spec.Containers[1].Image).To(Equal(DefaultStorageImage))
+
+			mount := v1.VolumeMount{MountPath: PodGatherRoot, Name: gatherVolumeName}
+			Expect(pod.Spec.Containers[0].VolumeMounts).To(ContainElement(mount))
+			Expect(pod.Spec.Containers[1].VolumeMounts).To(ConsistOf(mount))
+			Expect(pod.Spec.Volumes).To(ContainElement(v1.Volume{
+				Name:         gatherVolumeName,
+				VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}},
+			}))
+		})
+
+		It("uses the storage image set on the runner", func() {
+			r.GatherDir = "gathered"
+			r.StorageImage = "registry.example.com/busybox:1.33.0"
+			pod := getPodDefinition("cm", test, r)
+			Expect(pod.Spec.Containers[1].Image).To(Equal(r.StorageImage))
+		})
+	})
+
+	Describe("gatherDirName", func() {
+		pod := &v1.Pod{}
+		pod.Name = "scorecard-test-abcd"
+
+		It("uses the test label and pod name if the label is set", func() {
+			test := v1alpha3.TestConfiguration{Labels: map[string]string{"test": "basic-check-spec-test"}}
+			Expect(gatherDirName(pod, test)).To(Equal("basic-check-spec-test-scorecard-test-abcd"))
+		})
+		It("falls back to the pod name", func() {
+			Expect(gatherDirName(pod, v1alpha3.TestConfiguration{})).To(Equal(pod.Name))
+		})
+		It("ignores test labels that are not valid directory names", func() {
+			for _, label := range []string{"..", "../../x", "a/b", `a\b`, "."} {
+				test := v1alpha3.TestConfiguration{Labels: map[string]string{"test": label}}
+				Expect(gatherDirName(pod, test)).To(Equal(pod.Name), label)
+			}
+		})
+		It("gives tests with the same label different directories", func() {
+			test := v1alpha3.TestConfiguration{Labels: map[string]string{"test": "olm-bundle-validation-test"}}
+			other := &v1.Pod{}
+			other.Name = "scorecard-test-efgh"
+			Expect(gatherDirName(pod, test)).NotTo(Equal(gatherDirName(other, test)))
+		})
+	})
+
+	Describe("gatherTestDir", func() {
+		pod := &v1.Pod{}
+		pod.Name = "scorecard-test-abcd"
+
+		It("returns a directory in the gather directory", func() {
+			test := v1alpha3.TestConfiguration{Labels: map[string]string{"test": "basic-check-spec-test"}}
+			dir, err := gatherTestDir("gathered", pod, test)
+			Expect(err).NotTo(HaveOccurred())
+			root, err := filepath.Abs("gathered")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(dir).To(Equal(filepath.Join(root, "basic-check-spec-test-scorecard-test-abcd")))
+		})
+		It("does not escape the gather directory", func() {
+			test := v1alpha3.TestConfiguration{Labels: map[string]string{"test": "../../x"}}
+			dir, err := gatherTestDir("gathered", pod, test)
+			Expect(err).NotTo(HaveOccurred())
+			root, err := filepath.Abs("gathered")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(filepath.Dir(dir)).To(Equal(root))
+		})
+		It("fails for a pod name that escapes the gather directory", func() {
+			escaping := &v1.Pod{}
+			escaping.Name = ".."
+			_, err := gatherTestDir("gathered", escaping, v1alpha3.TestConfiguration{})
+			Expect(err).To(MatchError(ContainSubstring("is not in gathered")))
+		})
+	})
+})
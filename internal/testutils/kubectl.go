@@ -0,0 +1,115 @@
+// Copyright 2021 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testutils
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// WaitForServiceEndpoints polls the Endpoints of the Service name in tc's namespace
+// until at least minCount ready addresses exist, or timeout is reached.
+// A Service can exist without any backing pods, so this is a stronger check than
+// asserting the Service object itself exists.
+func (tc TestContext) WaitForServiceEndpoints(name string, minCount int, timeout time.Duration) error {
+	count := 0
+	var lastErr error
+	err := wait.PollImmediate(time.Second, timeout, func() (bool, error) {
+		// Only addresses in "addresses" are ready; unready ones are in "notReadyAddresses".
+		out, err := tc.Kubectl.Get(true, "endpoints", name, "-o", "jsonpath={.subsets[*].addresses[*].ip}")
+		if err != nil {
+			lastErr = err
+			return false, nil
+		}
+		lastErr = nil
+		count = len(strings.Fields(out))
+		return count >= minCount, nil
+	})
+	if err != nil {
+		if lastErr != nil {
+			return fmt.Errorf("service %q has %d ready endpoints, expected at least %d: %v", name, count, minCount, lastErr)
+		}
+		return fmt.Errorf("service %q has %d ready endpoints, expected at least %d", name, count, minCount)
+	}
+	return nil
+}
+
+// WaitForPVCBound polls the phase of the PersistentVolumeClaim name in tc's namespace
+// until it is Bound, or timeout is reached. On timeout the PVC's last phase and events
+// are included in the returned error to help diagnose why it did not bind.
+func (tc TestContext) WaitForPVCBound(name string, timeout time.Duration) error {
+	phase := ""
+	var lastErr error
+	err := wait.PollImmediate(time.Second, timeout, func() (bool, error) {
+		out, err := tc.Kubectl.Get(true, "persistentvolumeclaim", name, "-o", "jsonpath={.status.phase}")
+		if err != nil {
+			lastErr = err
+			return false, nil
+		}
+		lastErr = nil
+		phase = strings.TrimSpace(out)
+		return phase == "Bound", nil
+	})
+	if err == nil {
+		return nil
+	}
+
+	if lastErr != nil {
+		return fmt.Errorf("pvc %q is not bound: %v", name, lastErr)
+	}
+	events, evErr := tc.Kubectl.Get(true, "events",
+		"--field-selector", "involvedObject.kind=PersistentVolumeClaim,involvedObject.name="+name,
+		"--sort-by", ".lastTimestamp")
+	if evErr != nil {
+		events = fmt.Sprintf("error getting events: %v", evErr)
+	}
+	return fmt.Errorf("pvc %q is not bound, has phase %q, events:\n%s", name, phase, events)
+}
+
+// ExpectApplyRejected applies the manifest yaml in tc's namespace and returns an error
+// unless the apply fails with an error message containing expectedSubstring, ex. a
+// validating webhook's denial reason. If the apply unexpectedly succeeds, the applied
+// objects are deleted before returning.
+func (tc TestContext) ExpectApplyRejected(yaml, expectedSubstring string) error {
+	f, err := ioutil.TempFile("", "apply-rejected-*.yaml")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.WriteString(yaml); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	out, err := tc.Kubectl.Apply(true, "-f", f.Name())
+	if err == nil {
+		if _, delErr := tc.Kubectl.Delete(true, "-f", f.Name()); delErr != nil {
+			return fmt.Errorf("apply unexpectedly succeeded, and deleting applied objects failed: %v\n%s", delErr, out)
+		}
+		return fmt.Errorf("apply unexpectedly succeeded:\n%s", out)
+	}
+	if !strings.Contains(err.Error(), expectedSubstring) {
+		return fmt.Errorf("apply was rejected without expected message %q: %v", expectedSubstring, err)
+	}
+	return nil
+}